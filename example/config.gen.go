@@ -6,16 +6,24 @@ package example
 
 import (
 	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type APIConfig struct {
-	host       string
-	port       int
-	secret     string
-	reqTTL     time.Duration
-	respTTL    time.Duration
-	defaultReq *http.Request
+	host        string
+	port        int
+	secret      string
+	reqTTL      time.Duration
+	respTTL     time.Duration
+	defaultReq  *http.Request
+	publicURL   *url.URL
+	trustedNets []netip.Prefix
 
 	origin any
 }
@@ -44,20 +52,118 @@ func (c APIConfig) DefaultReq() *http.Request {
 	return c.defaultReq
 }
 
+// PublicURL Public URL of the API server.
+func (c APIConfig) PublicURL() *url.URL {
+	return c.publicURL
+}
+
+// TrustedNets Subnets to trust the X-Forwarded-For header from.
+func (c APIConfig) TrustedNets() []netip.Prefix {
+	return c.trustedNets
+}
+
 // NewAPIConfig is a constructor converting apiConfig into the APIConfig.
 func NewAPIConfig(dto apiConfig) APIConfig {
 	return APIConfig{
-		host:       dto.Host,
-		port:       dto.Port,
-		secret:     dto.Secret,
-		reqTTL:     dto.ReqTTL,
-		respTTL:    dto.RespTTL,
-		defaultReq: dto.DefaultReq,
+		host:        dto.Host,
+		port:        dto.Port,
+		secret:      dto.Secret,
+		reqTTL:      dto.ReqTTL,
+		respTTL:     dto.RespTTL,
+		defaultReq:  dto.DefaultReq,
+		publicURL:   dto.PublicURL,
+		trustedNets: dto.TrustedNets,
 
 		origin: dto,
 	}
 }
 
+// APIConfigReader is an interface of the APIConfig getters.
+type APIConfigReader interface {
+	Host() string
+	Port() int
+	Secret() string
+	ReqTTL() time.Duration
+	RespTTL() time.Duration
+	DefaultReq() *http.Request
+	PublicURL() *url.URL
+	TrustedNets() []netip.Prefix
+}
+
+var _ APIConfigReader = APIConfig{}
+
+// APIConfigMock is a APIConfigReader implementation with the settable values, e.g. for the tests.
+type APIConfigMock struct {
+	HostValue        string
+	PortValue        int
+	SecretValue      string
+	ReqTTLValue      time.Duration
+	RespTTLValue     time.Duration
+	DefaultReqValue  *http.Request
+	PublicURLValue   *url.URL
+	TrustedNetsValue []netip.Prefix
+}
+
+var _ APIConfigReader = (*APIConfigMock)(nil)
+
+func (m *APIConfigMock) Host() string {
+	return m.HostValue
+}
+
+func (m *APIConfigMock) Port() int {
+	return m.PortValue
+}
+
+func (m *APIConfigMock) Secret() string {
+	return m.SecretValue
+}
+
+func (m *APIConfigMock) ReqTTL() time.Duration {
+	return m.ReqTTLValue
+}
+
+func (m *APIConfigMock) RespTTL() time.Duration {
+	return m.RespTTLValue
+}
+
+func (m *APIConfigMock) DefaultReq() *http.Request {
+	return m.DefaultReqValue
+}
+
+func (m *APIConfigMock) PublicURL() *url.URL {
+	return m.PublicURLValue
+}
+
+func (m *APIConfigMock) TrustedNets() []netip.Prefix {
+	return m.TrustedNetsValue
+}
+
+// Equal checks if the APIConfig values are equal.
+func (c APIConfig) Equal(other APIConfig) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other APIConfig,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c APIConfig) Diff(other APIConfig) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c APIConfig) diff(prefix string, other APIConfig) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"Host", c.host, other.host, false)
+	changes = appendFieldChange(changes, prefix+"Port", c.port, other.port, false)
+	changes = appendFieldChange(changes, prefix+"Secret", c.secret, other.secret, true)
+	changes = appendFieldChange(changes, prefix+"ReqTTL", c.reqTTL, other.reqTTL, false)
+	changes = appendFieldChange(changes, prefix+"RespTTL", c.respTTL, other.respTTL, false)
+	changes = appendFieldChange(changes, prefix+"DefaultReq", c.defaultReq, other.defaultReq, false)
+	changes = appendFieldChange(changes, prefix+"PublicURL", c.publicURL, other.publicURL, false)
+	changes = appendFieldChange(changes, prefix+"TrustedNets", c.trustedNets, other.trustedNets, false)
+
+	return changes
+}
+
 type AppConfig struct {
 	instanceID  string
 	baseTraceID int
@@ -87,6 +193,8 @@ func (c AppConfig) Namespace() string {
 }
 
 // Domain Top-level domain for the cookies
+//
+// Deprecated: set the cookie domain in the reverse proxy
 func (c AppConfig) Domain() string {
 	return c.domain
 }
@@ -104,10 +212,77 @@ func NewAppConfig(dto appConfig) AppConfig {
 	}
 }
 
+// AppConfigReader is an interface of the AppConfig getters.
+type AppConfigReader interface {
+	InstanceID() string
+	BaseTraceID() int
+	Env() string
+	Namespace() string
+	Domain() string
+}
+
+var _ AppConfigReader = AppConfig{}
+
+// AppConfigMock is a AppConfigReader implementation with the settable values, e.g. for the tests.
+type AppConfigMock struct {
+	InstanceIDValue  string
+	BaseTraceIDValue int
+	EnvValue         string
+	NamespaceValue   string
+	DomainValue      string
+}
+
+var _ AppConfigReader = (*AppConfigMock)(nil)
+
+func (m *AppConfigMock) InstanceID() string {
+	return m.InstanceIDValue
+}
+
+func (m *AppConfigMock) BaseTraceID() int {
+	return m.BaseTraceIDValue
+}
+
+func (m *AppConfigMock) Env() string {
+	return m.EnvValue
+}
+
+func (m *AppConfigMock) Namespace() string {
+	return m.NamespaceValue
+}
+
+func (m *AppConfigMock) Domain() string {
+	return m.DomainValue
+}
+
+// Equal checks if the AppConfig values are equal.
+func (c AppConfig) Equal(other AppConfig) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other AppConfig,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c AppConfig) Diff(other AppConfig) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c AppConfig) diff(prefix string, other AppConfig) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"InstanceID", c.instanceID, other.instanceID, false)
+	changes = appendFieldChange(changes, prefix+"BaseTraceID", c.baseTraceID, other.baseTraceID, false)
+	changes = appendFieldChange(changes, prefix+"Env", c.env, other.env, false)
+	changes = appendFieldChange(changes, prefix+"Namespace", c.namespace, other.namespace, false)
+	changes = appendFieldChange(changes, prefix+"Domain", c.domain, other.domain, false)
+
+	return changes
+}
+
 type Config struct {
-	app    AppConfig
-	logger LoggerConfig
-	api    APIConfig
+	app      AppConfig
+	logger   LoggerConfig
+	api      APIConfig
+	pool     PoolConfig
+	upstream UpstreamConfig
 
 	origin any
 }
@@ -127,17 +302,94 @@ func (c Config) API() APIConfig {
 	return c.api
 }
 
+// Pool is a workers pool configuration.
+func (c Config) Pool() PoolConfig {
+	return c.pool
+}
+
+// Upstream is a proxied service with an optional fallback.
+func (c Config) Upstream() UpstreamConfig {
+	return c.upstream
+}
+
 // NewConfig is a constructor converting config into the Config.
 func NewConfig(dto config) Config {
 	return Config{
-		app:    NewAppConfig(dto.App),
-		logger: NewLoggerConfig(dto.Logger),
-		api:    NewAPIConfig(dto.API),
+		app:      NewAppConfig(dto.App),
+		logger:   NewLoggerConfig(dto.Logger),
+		api:      NewAPIConfig(dto.API),
+		pool:     NewPoolConfig(dto.Pool),
+		upstream: NewUpstreamConfig(dto.Upstream),
 
 		origin: dto,
 	}
 }
 
+// ConfigReader is an interface of the Config getters.
+type ConfigReader interface {
+	App() AppConfig
+	Logger() LoggerConfig
+	API() APIConfig
+	Pool() PoolConfig
+	Upstream() UpstreamConfig
+}
+
+var _ ConfigReader = Config{}
+
+// ConfigMock is a ConfigReader implementation with the settable values, e.g. for the tests.
+type ConfigMock struct {
+	AppValue      AppConfig
+	LoggerValue   LoggerConfig
+	APIValue      APIConfig
+	PoolValue     PoolConfig
+	UpstreamValue UpstreamConfig
+}
+
+var _ ConfigReader = (*ConfigMock)(nil)
+
+func (m *ConfigMock) App() AppConfig {
+	return m.AppValue
+}
+
+func (m *ConfigMock) Logger() LoggerConfig {
+	return m.LoggerValue
+}
+
+func (m *ConfigMock) API() APIConfig {
+	return m.APIValue
+}
+
+func (m *ConfigMock) Pool() PoolConfig {
+	return m.PoolValue
+}
+
+func (m *ConfigMock) Upstream() UpstreamConfig {
+	return m.UpstreamValue
+}
+
+// Equal checks if the Config values are equal.
+func (c Config) Equal(other Config) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other Config,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c Config) Diff(other Config) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c Config) diff(prefix string, other Config) []FieldChange {
+	var changes []FieldChange
+
+	changes = append(changes, c.app.diff(prefix+"App.", other.app)...)
+	changes = append(changes, c.logger.diff(prefix+"Logger.", other.logger)...)
+	changes = append(changes, c.api.diff(prefix+"API.", other.api)...)
+	changes = append(changes, c.pool.diff(prefix+"Pool.", other.pool)...)
+	changes = append(changes, c.upstream.diff(prefix+"Upstream.", other.upstream)...)
+
+	return changes
+}
+
 type GenericAppConfig struct {
 	instanceID  string
 	baseTraceID int
@@ -155,6 +407,7 @@ func (c GenericAppConfig) BaseTraceID() int {
 
 type LoggerConfig struct {
 	level         LogLevel
+	format        LogFormat
 	defaultFields struct {
 		traceID string
 		values  map[string]any
@@ -167,6 +420,10 @@ func (c LoggerConfig) Level() LogLevel {
 	return c.level
 }
 
+func (c LoggerConfig) Format() LogFormat {
+	return c.format
+}
+
 func (c LoggerConfig) DefaultFields() struct {
 	traceID string
 	values  map[string]any
@@ -177,7 +434,8 @@ func (c LoggerConfig) DefaultFields() struct {
 // NewLoggerConfig is a constructor converting loggerConfig into the LoggerConfig.
 func NewLoggerConfig(dto loggerConfig) LoggerConfig {
 	return LoggerConfig{
-		level: dto.Level,
+		level:  dto.Level,
+		format: dto.Format,
 		defaultFields: struct {
 			traceID string
 			values  map[string]any
@@ -190,6 +448,67 @@ func NewLoggerConfig(dto loggerConfig) LoggerConfig {
 	}
 }
 
+// LoggerConfigReader is an interface of the LoggerConfig getters.
+type LoggerConfigReader interface {
+	Level() LogLevel
+	Format() LogFormat
+	DefaultFields() struct {
+		traceID string
+		values  map[string]any
+	}
+}
+
+var _ LoggerConfigReader = LoggerConfig{}
+
+// LoggerConfigMock is a LoggerConfigReader implementation with the settable values, e.g. for the tests.
+type LoggerConfigMock struct {
+	LevelValue         LogLevel
+	FormatValue        LogFormat
+	DefaultFieldsValue struct {
+		traceID string
+		values  map[string]any
+	}
+}
+
+var _ LoggerConfigReader = (*LoggerConfigMock)(nil)
+
+func (m *LoggerConfigMock) Level() LogLevel {
+	return m.LevelValue
+}
+
+func (m *LoggerConfigMock) Format() LogFormat {
+	return m.FormatValue
+}
+
+func (m *LoggerConfigMock) DefaultFields() struct {
+	traceID string
+	values  map[string]any
+} {
+	return m.DefaultFieldsValue
+}
+
+// Equal checks if the LoggerConfig values are equal.
+func (c LoggerConfig) Equal(other LoggerConfig) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other LoggerConfig,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c LoggerConfig) Diff(other LoggerConfig) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c LoggerConfig) diff(prefix string, other LoggerConfig) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"Level", c.level, other.level, false)
+	changes = appendFieldChange(changes, prefix+"Format", c.format, other.format, false)
+	changes = appendFieldChange(changes, prefix+"DefaultFields.TraceID", c.defaultFields.traceID, other.defaultFields.traceID, false)
+	changes = appendFieldChange(changes, prefix+"DefaultFields.Values", c.defaultFields.values, other.defaultFields.values, false)
+
+	return changes
+}
+
 type LoggerConfigDefaultFieldsProvider struct {
 	traceID string
 	values  map[string]any
@@ -204,3 +523,535 @@ func (c LoggerConfigDefaultFieldsProvider) TraceID() string {
 func (c LoggerConfigDefaultFieldsProvider) Values() map[string]any {
 	return c.values
 }
+
+type OptionalTimeDuration struct {
+	value time.Duration
+	set   bool
+
+	origin any
+}
+
+// Value is used only if Set is true.
+func (c OptionalTimeDuration) Value() time.Duration {
+	return c.value
+}
+
+func (c OptionalTimeDuration) Set() bool {
+	return c.set
+}
+
+// NewOptionalTimeDuration is a constructor converting optional[time.Duration] into the OptionalTimeDuration.
+func NewOptionalTimeDuration(dto optional[time.Duration]) OptionalTimeDuration {
+	return OptionalTimeDuration{
+		value: dto.Value,
+		set:   dto.Set,
+
+		origin: dto,
+	}
+}
+
+// OptionalTimeDurationReader is an interface of the OptionalTimeDuration getters.
+type OptionalTimeDurationReader interface {
+	Value() time.Duration
+	Set() bool
+}
+
+var _ OptionalTimeDurationReader = OptionalTimeDuration{}
+
+// OptionalTimeDurationMock is a OptionalTimeDurationReader implementation with the settable values, e.g. for the tests.
+type OptionalTimeDurationMock struct {
+	ValueValue time.Duration
+	SetValue   bool
+}
+
+var _ OptionalTimeDurationReader = (*OptionalTimeDurationMock)(nil)
+
+func (m *OptionalTimeDurationMock) Value() time.Duration {
+	return m.ValueValue
+}
+
+func (m *OptionalTimeDurationMock) Set() bool {
+	return m.SetValue
+}
+
+// Equal checks if the OptionalTimeDuration values are equal.
+func (c OptionalTimeDuration) Equal(other OptionalTimeDuration) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other OptionalTimeDuration,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c OptionalTimeDuration) Diff(other OptionalTimeDuration) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c OptionalTimeDuration) diff(prefix string, other OptionalTimeDuration) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"Value", c.value, other.value, false)
+	changes = appendFieldChange(changes, prefix+"Set", c.set, other.set, false)
+
+	return changes
+}
+
+type PoolConfig struct {
+	size    int
+	workers ValueRangeInt
+	idle    OptionalTimeDuration
+
+	origin any
+}
+
+func (c PoolConfig) Size() int {
+	return c.size
+}
+
+func (c PoolConfig) Workers() ValueRangeInt {
+	return c.workers
+}
+
+func (c PoolConfig) Idle() OptionalTimeDuration {
+	return c.idle
+}
+
+// NewPoolConfig is a constructor converting poolConfig into the PoolConfig.
+func NewPoolConfig(dto poolConfig) PoolConfig {
+	return PoolConfig{
+		size:    dto.Size,
+		workers: NewValueRangeInt(dto.Workers),
+		idle:    NewOptionalTimeDuration(dto.Idle),
+
+		origin: dto,
+	}
+}
+
+// PoolConfigReader is an interface of the PoolConfig getters.
+type PoolConfigReader interface {
+	Size() int
+	Workers() ValueRangeInt
+	Idle() OptionalTimeDuration
+}
+
+var _ PoolConfigReader = PoolConfig{}
+
+// PoolConfigMock is a PoolConfigReader implementation with the settable values, e.g. for the tests.
+type PoolConfigMock struct {
+	SizeValue    int
+	WorkersValue ValueRangeInt
+	IdleValue    OptionalTimeDuration
+}
+
+var _ PoolConfigReader = (*PoolConfigMock)(nil)
+
+func (m *PoolConfigMock) Size() int {
+	return m.SizeValue
+}
+
+func (m *PoolConfigMock) Workers() ValueRangeInt {
+	return m.WorkersValue
+}
+
+func (m *PoolConfigMock) Idle() OptionalTimeDuration {
+	return m.IdleValue
+}
+
+// Equal checks if the PoolConfig values are equal.
+func (c PoolConfig) Equal(other PoolConfig) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other PoolConfig,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c PoolConfig) Diff(other PoolConfig) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c PoolConfig) diff(prefix string, other PoolConfig) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"Size", c.size, other.size, false)
+	changes = append(changes, c.workers.diff(prefix+"Workers.", other.workers)...)
+	changes = append(changes, c.idle.diff(prefix+"Idle.", other.idle)...)
+
+	return changes
+}
+
+type UpstreamConfig struct {
+	url      string
+	fallback *UpstreamConfig
+	mirrors  []upstreamConfig
+	token    string
+
+	origin any
+}
+
+func (c UpstreamConfig) URL() string {
+	return c.url
+}
+
+func (c UpstreamConfig) Fallback() *UpstreamConfig {
+	return c.fallback
+}
+
+func (c UpstreamConfig) Mirrors() []upstreamConfig {
+	return c.mirrors
+}
+
+func (c UpstreamConfig) Token() string {
+	return c.token
+}
+
+// NewUpstreamConfig is a constructor converting upstreamConfig into the UpstreamConfig.
+func NewUpstreamConfig(dto upstreamConfig) UpstreamConfig {
+	return UpstreamConfig{
+		url:      dto.URL,
+		fallback: NewUpstreamConfigPtr(dto.Fallback),
+		mirrors:  dto.Mirrors,
+		token:    dto.Token,
+
+		origin: dto,
+	}
+}
+
+// NewUpstreamConfigPtr is a constructor converting *upstreamConfig into the *UpstreamConfig.
+func NewUpstreamConfigPtr(dto *upstreamConfig) *UpstreamConfig {
+	if dto == nil {
+		return nil
+	}
+
+	v := NewUpstreamConfig(*dto)
+
+	return &v
+}
+
+// UpstreamConfigReader is an interface of the UpstreamConfig getters.
+type UpstreamConfigReader interface {
+	URL() string
+	Fallback() *UpstreamConfig
+	Mirrors() []upstreamConfig
+	Token() string
+}
+
+var _ UpstreamConfigReader = UpstreamConfig{}
+
+// UpstreamConfigMock is a UpstreamConfigReader implementation with the settable values, e.g. for the tests.
+type UpstreamConfigMock struct {
+	URLValue      string
+	FallbackValue *UpstreamConfig
+	MirrorsValue  []upstreamConfig
+	TokenValue    string
+}
+
+var _ UpstreamConfigReader = (*UpstreamConfigMock)(nil)
+
+func (m *UpstreamConfigMock) URL() string {
+	return m.URLValue
+}
+
+func (m *UpstreamConfigMock) Fallback() *UpstreamConfig {
+	return m.FallbackValue
+}
+
+func (m *UpstreamConfigMock) Mirrors() []upstreamConfig {
+	return m.MirrorsValue
+}
+
+func (m *UpstreamConfigMock) Token() string {
+	return m.TokenValue
+}
+
+// Equal checks if the UpstreamConfig values are equal.
+func (c UpstreamConfig) Equal(other UpstreamConfig) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other UpstreamConfig,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c UpstreamConfig) Diff(other UpstreamConfig) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c UpstreamConfig) diff(prefix string, other UpstreamConfig) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"URL", c.url, other.url, false)
+	if c.fallback != nil && other.fallback != nil {
+		changes = append(changes, c.fallback.diff(prefix+"Fallback.", *other.fallback)...)
+	} else {
+		changes = appendFieldChange(changes, prefix+"Fallback", c.fallback, other.fallback, true)
+	}
+	changes = appendFieldChange(changes, prefix+"Mirrors", c.mirrors, other.mirrors, true)
+	changes = appendFieldChange(changes, prefix+"Token", c.token, other.token, true)
+
+	return changes
+}
+
+type ValueRangeInt struct {
+	min int
+	max int
+
+	origin any
+}
+
+func (c ValueRangeInt) Min() int {
+	return c.min
+}
+
+func (c ValueRangeInt) Max() int {
+	return c.max
+}
+
+// NewValueRangeInt is a constructor converting valueRange[int] into the ValueRangeInt.
+func NewValueRangeInt(dto valueRange[int]) ValueRangeInt {
+	return ValueRangeInt{
+		min: dto.Min,
+		max: dto.Max,
+
+		origin: dto,
+	}
+}
+
+// ValueRangeIntReader is an interface of the ValueRangeInt getters.
+type ValueRangeIntReader interface {
+	Min() int
+	Max() int
+}
+
+var _ ValueRangeIntReader = ValueRangeInt{}
+
+// ValueRangeIntMock is a ValueRangeIntReader implementation with the settable values, e.g. for the tests.
+type ValueRangeIntMock struct {
+	MinValue int
+	MaxValue int
+}
+
+var _ ValueRangeIntReader = (*ValueRangeIntMock)(nil)
+
+func (m *ValueRangeIntMock) Min() int {
+	return m.MinValue
+}
+
+func (m *ValueRangeIntMock) Max() int {
+	return m.MaxValue
+}
+
+// Equal checks if the ValueRangeInt values are equal.
+func (c ValueRangeInt) Equal(other ValueRangeInt) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other ValueRangeInt,
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c ValueRangeInt) Diff(other ValueRangeInt) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c ValueRangeInt) diff(prefix string, other ValueRangeInt) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendFieldChange(changes, prefix+"Min", c.min, other.min, false)
+	changes = appendFieldChange(changes, prefix+"Max", c.max, other.max, false)
+
+	return changes
+}
+
+// FieldChange is a config field changed between two values of the generated struct.
+type FieldChange struct {
+	// Path is a field path from the compared struct, e.g. `API.Port`.
+	Path string
+
+	// Old is a field value before the change.
+	Old any
+
+	// New is a field value after the change.
+	New any
+}
+
+// FieldChangeSecretMask replaces the values of the secret fields in the FieldChange.
+const FieldChangeSecretMask = "******"
+
+func appendFieldChange(changes []FieldChange, path string, old, new any, isSecret bool) []FieldChange {
+	if reflect.DeepEqual(old, new) {
+		return changes
+	}
+
+	if isSecret {
+		old, new = FieldChangeSecretMask, FieldChangeSecretMask
+	}
+
+	return append(changes, FieldChange{Path: path, Old: old, New: new})
+}
+
+// ConfigStore is a concurrency-safe holder of the Config snapshot, e.g. for the config hot reload.
+type ConfigStore struct {
+	value atomic.Pointer[Config]
+
+	// swapMu serializes the swaps with their notifications, mu guards the subscribers.
+	swapMu      sync.Mutex
+	mu          sync.Mutex
+	subscribers []*func(old, new Config)
+}
+
+// NewConfigStore creates the ConfigStore holding the given value.
+// Zero ConfigStore holds the zero Config value.
+func NewConfigStore(value Config) *ConfigStore {
+	s := &ConfigStore{}
+	s.value.Store(&value)
+
+	return s
+}
+
+// Load returns the current Config snapshot.
+func (s *ConfigStore) Load() Config {
+	return s.deref(s.value.Load())
+}
+
+// Swap replaces the current Config snapshot and returns the previous one.
+// Subscribers are called synchronously in the caller goroutine after the value is replaced.
+// Concurrent swaps wait for the previous notifications, so the subscribers get the swaps in order
+// and must not call Swap or Reload themselves.
+func (s *ConfigStore) Swap(value Config) Config {
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+
+	old := s.deref(s.value.Swap(&value))
+
+	s.mu.Lock()
+	subscribers := append(s.subscribers[:0:0], s.subscribers...)
+	s.mu.Unlock()
+
+	for _, fn := range subscribers {
+		(*fn)(old, value)
+	}
+
+	return old
+}
+
+// Reload loads the config with the given loader function and swaps the current snapshot.
+// Current snapshot is kept if loader fails.
+// The store doesn't watch the config files, Reload is called by the application, e.g. on SIGHUP.
+func (s *ConfigStore) Reload(load func() (config, error)) error {
+	dto, err := load()
+	if err != nil {
+		return err
+	}
+
+	s.Swap(NewConfig(dto))
+
+	return nil
+}
+
+// Subscribe registers the function called on each snapshot swap.
+// Returned function cancels the subscription.
+func (s *ConfigStore) Subscribe(fn func(old, new Config)) (unsubscribe func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub := &fn
+	s.subscribers = append(s.subscribers, sub)
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i, registered := range s.subscribers {
+			if registered == sub {
+				s.subscribers = append(s.subscribers[:i:i], s.subscribers[i+1:]...)
+
+				break
+			}
+		}
+	}
+}
+
+func (s *ConfigStore) deref(value *Config) Config {
+	if value == nil {
+		return Config{}
+	}
+
+	return *value
+}
+
+// ResolveConfigEnvAliases sets the dotenv variables from their deprecated aliases
+// if the variables are not set, warn is called for each used alias.
+// Call it before loading the config from the environment.
+func ResolveConfigEnvAliases(warn func(alias, name string)) error {
+	aliases := []struct {
+		name    string
+		aliases []string
+	}{
+		{"API_RESP_TTL", []string{"API_RESPONSE_TTL"}},
+	}
+
+	for _, a := range aliases {
+		if _, ok := os.LookupEnv(a.name); ok {
+			continue
+		}
+
+		for _, alias := range a.aliases {
+			value, ok := os.LookupEnv(alias)
+			if !ok {
+				continue
+			}
+
+			if err := os.Setenv(a.name, value); err != nil {
+				return err
+			}
+
+			if warn != nil {
+				warn(alias, a.name)
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// ResolveConfigYAMLAliases renames the deprecated YAML keys aliases in the decoded values
+// if the keys are not set, warn is called for each used alias with the full keys paths.
+// Call it on the values decoded from the YAML into the `map[string]any` before decoding them into the struct.
+func ResolveConfigYAMLAliases(values map[string]any, warn func(alias, key string)) {
+	aliases := []struct {
+		parent  []string
+		prefix  string
+		key     string
+		aliases []string
+	}{
+		{[]string{"api"}, "api.", "resp_ttl", []string{"response_ttl"}},
+	}
+
+	for _, a := range aliases {
+		mapping := values
+		for _, key := range a.parent {
+			if mapping, _ = mapping[key].(map[string]any); mapping == nil {
+				break
+			}
+		}
+
+		if mapping == nil {
+			continue
+		}
+
+		if _, ok := mapping[a.key]; ok {
+			continue
+		}
+
+		for _, alias := range a.aliases {
+			value, ok := mapping[alias]
+			if !ok {
+				continue
+			}
+
+			mapping[a.key] = value
+			delete(mapping, alias)
+
+			if warn != nil {
+				warn(a.prefix+alias, a.prefix+a.key)
+			}
+
+			break
+		}
+	}
+}
@@ -3,13 +3,14 @@ package example
 import (
 	"errors"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"time"
 )
 
 // Added as an example usage.
 // To regenerate example files in the configen repository, use `make generate_example`.
-//go:generate go tool configen --struct=config --yaml=true --env=config.env --go=config.gen.go
-//go:generate go tool configen --struct=config --yaml=local.yaml --env=local.env --yaml-tag=local --value-tag=localDefault --env-prefix-tag=envPrefix
+//go:generate go tool configen --struct=config --yaml=true --env=config.env --go=config.gen.go --go-mocks=true --go-store=true --go-diff=true --go-fixture=config_fixture_test.go --flags=config_flags.gen.go --template=config.md.tpl:config.md --profile=local:tag=local,value=localDefault:yaml=local.yaml,env=local.env --profile-report=profiles.md
 
 // Config godoc
 //
@@ -25,6 +26,12 @@ type config struct {
 
 	// API is an API server configuration.
 	API apiConfig `envPrefix:"API_" json:"api" yaml:"api" local:"API"`
+
+	// Pool is a workers pool configuration.
+	Pool poolConfig `envPrefix:"POOL_" json:"pool" yaml:"pool" local:"pool"`
+
+	// Upstream is a proxied service with an optional fallback.
+	Upstream upstreamConfig `envPrefix:"UPSTREAM_" json:"upstream" yaml:"upstream" local:"-"`
 }
 
 type genericAppConfig struct {
@@ -42,11 +49,12 @@ type appConfig struct {
 	Namespace string `env:"NAMESPACE" envDefault:"unknown" json:"namespace" yaml:"namespace" local:"namespace" localDefault:"local"`
 
 	// Top-level domain for the cookies
-	Domain string `json:"domain" yaml:"domain" local:"domain" localDefault:"localhost"`
+	Domain string `json:"domain" yaml:"domain" local:"domain" localDefault:"localhost" deprecated:"set the cookie domain in the reverse proxy"`
 }
 
 type loggerConfig struct {
-	Level LogLevel `env:"LEVEL" envDefault:"debug" json:"level" yaml:"level"`
+	Level  LogLevel  `env:"LEVEL" envDefault:"debug" json:"level" yaml:"level"`
+	Format LogFormat `env:"FORMAT" envDefault:"text" json:"format" yaml:"format" localDefault:"json"`
 
 	DefaultFields struct {
 		TraceID string         `env:"TRACE_ID" json:"trace_id" yaml:"trace_id"`
@@ -55,14 +63,51 @@ type loggerConfig struct {
 }
 
 type apiConfig struct {
-	Host       string        `env:"HOST" envDefault:"0.0.0.0" json:"host" yaml:"host"`
+	Host       string        `env:"HOST,required" envDefault:"0.0.0.0" json:"host" yaml:"host" validate:"required"`
 	Port       int           `env:"PORT" envDefault:"8080" json:"port" yaml:"port"`
-	Secret     string        `env:"SECRET,unset" envDefault:"secret" json:"secret" yaml:"secret"`
+	Secret     string        `env:"SECRET,unset" envDefault:"secret" json:"secret" yaml:"secret" secret:"true" flag:"-"`
 	ReqTTL     time.Duration `env:"REQ_TTL" envDefault:"1h" json:"req_ttl" yaml:"req_ttl"`
-	RespTTL    time.Duration `env:"RESP_TTL" envDefault:"1h" json:"resp_ttl" yaml:"resp_ttl"`
+	RespTTL    time.Duration `env:"RESP_TTL" envDefault:"1h" json:"resp_ttl" yaml:"resp_ttl" envAliases:"RESPONSE_TTL" yamlAliases:"response_ttl"`
 	DefaultReq *http.Request `yaml:"-" local:"-"`
+
+	// Public URL of the API server.
+	PublicURL *url.URL `env:"PUBLIC_URL" envDefault:"http://localhost:8080" json:"public_url" yaml:"public_url"`
+
+	// Subnets to trust the X-Forwarded-For header from.
+	TrustedNets []netip.Prefix `env:"TRUSTED_NETS" envDefault:"10.0.0.0/8,172.16.0.0/12" json:"trusted_nets" yaml:"trusted_nets"`
+}
+
+type poolConfig struct {
+	Size    int                     `env:"SIZE" default:"10" json:"size" yaml:"size" local:"size" localDefault:"2"`
+	Workers valueRange[int]         `envPrefix:"WORKERS_" json:"workers" yaml:"workers" local:"workers"`
+	Idle    optional[time.Duration] `envPrefix:"IDLE_" json:"idle" yaml:"idle" local:"idle"`
+}
+
+type upstreamConfig struct {
+	URL      string           `env:"URL" default:"http://localhost:8081" json:"url" yaml:"url"`
+	Fallback *upstreamConfig  `envPrefix:"FALLBACK_" json:"fallback" yaml:"fallback"`
+	Mirrors  []upstreamConfig `json:"mirrors" yaml:"mirrors"`
+	Token    string           `env:"TOKEN" json:"token" yaml:"token" secret:"true" flag:"-"`
 }
 
+type valueRange[T int | float64] struct {
+	Min T `env:"MIN" default:"1" json:"min" yaml:"min" local:"min"`
+	Max T `env:"MAX" default:"4" json:"max" yaml:"max" local:"max"`
+}
+
+type optional[T any] struct {
+	// Value is used only if Set is true.
+	Value T    `env:"VALUE" json:"value" yaml:"value" local:"value"`
+	Set   bool `env:"SET" json:"set" yaml:"set" local:"set"`
+}
+
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
 type LogLevel int
 
 func (l *LogLevel) MarshalText() ([]byte, error) {
@@ -0,0 +1,11 @@
+# {{ .StructName | camel }} environment variables
+
+{{ comment "> " .Doc }}
+
+| Variable | Type | Default | Description |
+|----------|------|---------|-------------|
+{{- range .Values }}
+{{- if .EnvName }}
+| `{{ .EnvName }}` | `{{ .Type }}`{{ with .Format }} ({{ . }}){{ end }} | {{ with .EnvDefault }}`{{ . }}`{{ end }} | {{ with .Comment }}{{ . | replace "\n" " " | replace "|" "\\|" }} {{ end }}{{ with .Enum }}Allowed values: {{ join ", " . }}.{{ end }}{{ with .Deprecated }} **Deprecated:** {{ . }}.{{ end }}{{ with .EnvAliases }} Aliases: `{{ join "`, `" . }}`.{{ end }} |
+{{- end }}
+{{- end }}
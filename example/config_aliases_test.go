@@ -0,0 +1,133 @@
+package example
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveConfigEnvAliases(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Env      map[string]string
+		Expected string
+		Warned   []string
+	}{
+		{
+			Name:     "alias is resolved",
+			Env:      map[string]string{"API_RESPONSE_TTL": "2h"},
+			Expected: "2h",
+			Warned:   []string{"API_RESPONSE_TTL -> API_RESP_TTL"},
+		},
+		{
+			Name:     "name wins over alias",
+			Env:      map[string]string{"API_RESP_TTL": "3h", "API_RESPONSE_TTL": "2h"},
+			Expected: "3h",
+		},
+		{
+			Name:     "empty name wins over alias",
+			Env:      map[string]string{"API_RESP_TTL": "", "API_RESPONSE_TTL": "2h"},
+			Expected: "",
+		},
+		{
+			Name: "nothing is set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for _, name := range []string{"API_RESP_TTL", "API_RESPONSE_TTL"} {
+				unsetEnv(t, name)
+			}
+
+			for name, value := range test.Env {
+				t.Setenv(name, value)
+			}
+
+			var warned []string
+
+			err := ResolveConfigEnvAliases(func(alias, name string) {
+				warned = append(warned, alias+" -> "+name)
+			})
+			require.NoError(t, err)
+
+			value, ok := os.LookupEnv("API_RESP_TTL")
+			assert.Equal(t, test.Expected, value)
+			assert.Equal(t, test.Env != nil, ok)
+			assert.Equal(t, test.Warned, warned)
+		})
+	}
+}
+
+func TestResolveConfigEnvAliases_NilWarn(t *testing.T) {
+	unsetEnv(t, "API_RESP_TTL")
+	t.Setenv("API_RESPONSE_TTL", "2h")
+
+	require.NoError(t, ResolveConfigEnvAliases(nil))
+	assert.Equal(t, "2h", os.Getenv("API_RESP_TTL"))
+}
+
+func TestResolveConfigYAMLAliases(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Values   map[string]any
+		Expected map[string]any
+		Warned   []string
+	}{
+		{
+			Name:     "alias is resolved",
+			Values:   map[string]any{"api": map[string]any{"port": 80, "response_ttl": "2h"}},
+			Expected: map[string]any{"api": map[string]any{"port": 80, "resp_ttl": "2h"}},
+			Warned:   []string{"api.response_ttl -> api.resp_ttl"},
+		},
+		{
+			Name:     "key wins over alias",
+			Values:   map[string]any{"api": map[string]any{"resp_ttl": "3h", "response_ttl": "2h"}},
+			Expected: map[string]any{"api": map[string]any{"resp_ttl": "3h", "response_ttl": "2h"}},
+		},
+		{
+			Name:     "null key wins over alias",
+			Values:   map[string]any{"api": map[string]any{"resp_ttl": nil, "response_ttl": "2h"}},
+			Expected: map[string]any{"api": map[string]any{"resp_ttl": nil, "response_ttl": "2h"}},
+		},
+		{
+			Name:     "alias outside of its parent is kept",
+			Values:   map[string]any{"response_ttl": "2h"},
+			Expected: map[string]any{"response_ttl": "2h"},
+		},
+		{
+			Name:     "parent is not a mapping",
+			Values:   map[string]any{"api": "none"},
+			Expected: map[string]any{"api": "none"},
+		},
+		{
+			Name:     "empty values",
+			Values:   map[string]any{},
+			Expected: map[string]any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var warned []string
+
+			ResolveConfigYAMLAliases(test.Values, func(alias, key string) {
+				warned = append(warned, alias+" -> "+key)
+			})
+
+			assert.Equal(t, test.Expected, test.Values)
+			assert.Equal(t, test.Warned, warned)
+		})
+	}
+}
+
+// unsetEnv unsets the environment variable until the test end.
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+
+	// Setenv registers the restoring of the original value.
+	t.Setenv(name, "")
+	require.NoError(t, os.Unsetenv(name))
+}
@@ -0,0 +1,179 @@
+package example
+
+import (
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Diff(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Change   func(dto *config)
+		Expected []FieldChange
+	}{
+		{
+			Name:   "equal values",
+			Change: func(*config) {},
+		},
+		{
+			Name: "nested field",
+			Change: func(dto *config) {
+				dto.Pool.Workers.Max = 8
+			},
+			Expected: []FieldChange{{Path: "Pool.Workers.Max", Old: 4, New: 8}},
+		},
+		{
+			Name: "anonymous struct field",
+			Change: func(dto *config) {
+				dto.Logger.DefaultFields.TraceID = "trace"
+			},
+			Expected: []FieldChange{{Path: "Logger.DefaultFields.TraceID", Old: "", New: "trace"}},
+		},
+		{
+			Name: "map field",
+			Change: func(dto *config) {
+				dto.Logger.DefaultFields.Values = map[string]any{"service": "api"}
+			},
+			Expected: []FieldChange{{
+				Path: "Logger.DefaultFields.Values",
+				Old:  map[string]any{"service": "example"},
+				New:  map[string]any{"service": "api"},
+			}},
+		},
+		{
+			Name: "pointer field",
+			Change: func(dto *config) {
+				dto.API.PublicURL = mustParseURL(t, "https://example.com")
+			},
+			Expected: []FieldChange{{
+				Path: "API.PublicURL",
+				Old:  mustParseURL(t, "http://localhost:8080"),
+				New:  mustParseURL(t, "https://example.com"),
+			}},
+		},
+		{
+			Name: "slice field",
+			Change: func(dto *config) {
+				dto.API.TrustedNets = dto.API.TrustedNets[:1]
+			},
+			Expected: []FieldChange{{
+				Path: "API.TrustedNets",
+				Old:  []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("172.16.0.0/12")},
+				New:  []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			}},
+		},
+		{
+			Name: "pointer struct fields",
+			Change: func(dto *config) {
+				dto.Upstream.Fallback.URL = "http://fallback:8081"
+				dto.Upstream.Fallback.Token = "changed"
+			},
+			Expected: []FieldChange{
+				{Path: "Upstream.Fallback.URL", Old: "http://localhost:8082", New: "http://fallback:8081"},
+				{Path: "Upstream.Fallback.Token", Old: FieldChangeSecretMask, New: FieldChangeSecretMask},
+			},
+		},
+		{
+			Name: "nil pointer struct containing secret",
+			Change: func(dto *config) {
+				dto.Upstream.Fallback = nil
+			},
+			Expected: []FieldChange{{Path: "Upstream.Fallback", Old: FieldChangeSecretMask, New: FieldChangeSecretMask}},
+		},
+		{
+			Name: "slice of structs containing secret",
+			Change: func(dto *config) {
+				dto.Upstream.Mirrors = append(dto.Upstream.Mirrors, upstreamConfig{URL: "http://mirror:8081"})
+			},
+			Expected: []FieldChange{{Path: "Upstream.Mirrors", Old: FieldChangeSecretMask, New: FieldChangeSecretMask}},
+		},
+		{
+			Name: "secret fields",
+			Change: func(dto *config) {
+				dto.API.Secret = "changed"
+				dto.Upstream.Token = "changed"
+			},
+			Expected: []FieldChange{
+				{Path: "API.Secret", Old: FieldChangeSecretMask, New: FieldChangeSecretMask},
+				{Path: "Upstream.Token", Old: FieldChangeSecretMask, New: FieldChangeSecretMask},
+			},
+		},
+		{
+			Name: "fields of different structs",
+			Change: func(dto *config) {
+				dto.App.Env = "production"
+				dto.API.Port = 80
+			},
+			Expected: []FieldChange{
+				{Path: "App.Env", Old: "development", New: "production"},
+				{Path: "API.Port", Old: 8080, New: 80},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			old := NewConfig(newDiffDTO(t))
+
+			dto := newDiffDTO(t)
+			test.Change(&dto)
+			changed := NewConfig(dto)
+
+			assert.Equal(t, test.Expected, old.Diff(changed))
+			assert.Equal(t, len(test.Expected) == 0, old.Equal(changed))
+			assert.Equal(t, len(test.Expected) == 0, changed.Equal(old))
+		})
+	}
+}
+
+func TestConfig_DiffPrefix(t *testing.T) {
+	old := NewConfig(newDiffDTO(t))
+
+	dto := newDiffDTO(t)
+	dto.Pool.Workers.Min = 2
+
+	// Diff of the nested struct has the paths relative to it.
+	assert.Equal(t,
+		[]FieldChange{{Path: "Workers.Min", Old: 1, New: 2}},
+		old.Pool().Diff(NewConfig(dto).Pool()),
+	)
+	assert.Equal(t,
+		[]FieldChange{{Path: "Min", Old: 1, New: 2}},
+		old.Pool().Workers().Diff(NewConfig(dto).Pool().Workers()),
+	)
+}
+
+// newDiffDTO returns the config value with the new pointers, slices and maps on each call.
+func newDiffDTO(t *testing.T) config {
+	t.Helper()
+
+	dto := config{}
+	dto.App.Env = "development"
+	dto.Logger.DefaultFields.Values = map[string]any{"service": "example"}
+	dto.API.Port = 8080
+	dto.API.Secret = "secret"
+	dto.API.PublicURL = mustParseURL(t, "http://localhost:8080")
+	dto.API.TrustedNets = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("172.16.0.0/12")}
+	dto.Pool.Workers = valueRange[int]{Min: 1, Max: 4}
+	dto.Upstream = upstreamConfig{
+		URL:      "http://localhost:8081",
+		Fallback: &upstreamConfig{URL: "http://localhost:8082", Token: "fallback"},
+		Mirrors:  []upstreamConfig{{URL: "http://localhost:8083"}},
+		Token:    "token",
+	}
+
+	return dto
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+
+	return u
+}
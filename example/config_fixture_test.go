@@ -0,0 +1,272 @@
+// Code generated by github.com/kukymbr/configen; DO NOT EDIT.
+// Generator version: unknown (revision unknown, built at 2025-10-04 00:00:00)
+
+package example
+
+import (
+	"encoding"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// ConfigBuilder builds the Config for the tests, starting from the default values.
+type ConfigBuilder struct {
+	dto config
+}
+
+// NewConfigBuilder returns the ConfigBuilder with the default values.
+func NewConfigBuilder() *ConfigBuilder {
+	b := &ConfigBuilder{}
+
+	b.dto.App.InstanceID = "test"
+	b.dto.App.Env = "development"
+	b.dto.App.Namespace = "unknown"
+	b.dto.Logger.Level = configFixtureText[LogLevel]("debug")
+	b.dto.Logger.Format = LogFormat("text")
+	b.dto.API.Host = "0.0.0.0"
+	b.dto.API.Port = 8080
+	b.dto.API.Secret = "secret"
+	b.dto.API.ReqTTL = configFixtureMust(time.ParseDuration("1h"))
+	b.dto.API.RespTTL = configFixtureMust(time.ParseDuration("1h"))
+	b.dto.API.PublicURL = configFixtureMust(url.Parse("http://localhost:8080"))
+	b.dto.API.TrustedNets = []netip.Prefix{configFixtureText[netip.Prefix]("10.0.0.0/8"), configFixtureText[netip.Prefix]("172.16.0.0/12")}
+	b.dto.Pool.Size = 10
+	b.dto.Pool.Workers.Min = 1
+	b.dto.Pool.Workers.Max = 4
+	b.dto.Upstream.URL = "http://localhost:8081"
+
+	return b
+}
+
+// NewTestConfig returns the Config with the default values modified by the given functions.
+func NewTestConfig(opts ...func(dto *config)) Config {
+	b := NewConfigBuilder()
+
+	for _, opt := range opts {
+		b.With(opt)
+	}
+
+	return b.Build()
+}
+
+// With modifies the source struct with the given function.
+func (b *ConfigBuilder) With(fn func(dto *config)) *ConfigBuilder {
+	fn(&b.dto)
+
+	return b
+}
+
+// WithAppInstanceID sets the App.InstanceID value.
+func (b *ConfigBuilder) WithAppInstanceID(v string) *ConfigBuilder {
+	b.dto.App.InstanceID = v
+
+	return b
+}
+
+// WithAppBaseTraceID sets the App.BaseTraceID value.
+func (b *ConfigBuilder) WithAppBaseTraceID(v int) *ConfigBuilder {
+	b.dto.App.BaseTraceID = v
+
+	return b
+}
+
+// WithAppEnv sets the App.Env value.
+func (b *ConfigBuilder) WithAppEnv(v string) *ConfigBuilder {
+	b.dto.App.Env = v
+
+	return b
+}
+
+// WithAppNamespace sets the App.Namespace value.
+func (b *ConfigBuilder) WithAppNamespace(v string) *ConfigBuilder {
+	b.dto.App.Namespace = v
+
+	return b
+}
+
+// WithAppDomain sets the App.Domain value.
+func (b *ConfigBuilder) WithAppDomain(v string) *ConfigBuilder {
+	b.dto.App.Domain = v
+
+	return b
+}
+
+// WithLoggerLevel sets the Logger.Level value.
+func (b *ConfigBuilder) WithLoggerLevel(v LogLevel) *ConfigBuilder {
+	b.dto.Logger.Level = v
+
+	return b
+}
+
+// WithLoggerFormat sets the Logger.Format value.
+func (b *ConfigBuilder) WithLoggerFormat(v LogFormat) *ConfigBuilder {
+	b.dto.Logger.Format = v
+
+	return b
+}
+
+// WithLoggerDefaultFieldsTraceID sets the Logger.DefaultFields.TraceID value.
+func (b *ConfigBuilder) WithLoggerDefaultFieldsTraceID(v string) *ConfigBuilder {
+	b.dto.Logger.DefaultFields.TraceID = v
+
+	return b
+}
+
+// WithLoggerDefaultFieldsValues sets the Logger.DefaultFields.Values value.
+func (b *ConfigBuilder) WithLoggerDefaultFieldsValues(v map[string]any) *ConfigBuilder {
+	b.dto.Logger.DefaultFields.Values = v
+
+	return b
+}
+
+// WithAPIHost sets the API.Host value.
+func (b *ConfigBuilder) WithAPIHost(v string) *ConfigBuilder {
+	b.dto.API.Host = v
+
+	return b
+}
+
+// WithAPIPort sets the API.Port value.
+func (b *ConfigBuilder) WithAPIPort(v int) *ConfigBuilder {
+	b.dto.API.Port = v
+
+	return b
+}
+
+// WithAPISecret sets the API.Secret value.
+func (b *ConfigBuilder) WithAPISecret(v string) *ConfigBuilder {
+	b.dto.API.Secret = v
+
+	return b
+}
+
+// WithAPIReqTTL sets the API.ReqTTL value.
+func (b *ConfigBuilder) WithAPIReqTTL(v time.Duration) *ConfigBuilder {
+	b.dto.API.ReqTTL = v
+
+	return b
+}
+
+// WithAPIRespTTL sets the API.RespTTL value.
+func (b *ConfigBuilder) WithAPIRespTTL(v time.Duration) *ConfigBuilder {
+	b.dto.API.RespTTL = v
+
+	return b
+}
+
+// WithAPIDefaultReq sets the API.DefaultReq value.
+func (b *ConfigBuilder) WithAPIDefaultReq(v *http.Request) *ConfigBuilder {
+	b.dto.API.DefaultReq = v
+
+	return b
+}
+
+// WithAPIPublicURL sets the API.PublicURL value.
+func (b *ConfigBuilder) WithAPIPublicURL(v *url.URL) *ConfigBuilder {
+	b.dto.API.PublicURL = v
+
+	return b
+}
+
+// WithAPITrustedNets sets the API.TrustedNets value.
+func (b *ConfigBuilder) WithAPITrustedNets(v []netip.Prefix) *ConfigBuilder {
+	b.dto.API.TrustedNets = v
+
+	return b
+}
+
+// WithPoolSize sets the Pool.Size value.
+func (b *ConfigBuilder) WithPoolSize(v int) *ConfigBuilder {
+	b.dto.Pool.Size = v
+
+	return b
+}
+
+// WithPoolWorkersMin sets the Pool.Workers.Min value.
+func (b *ConfigBuilder) WithPoolWorkersMin(v int) *ConfigBuilder {
+	b.dto.Pool.Workers.Min = v
+
+	return b
+}
+
+// WithPoolWorkersMax sets the Pool.Workers.Max value.
+func (b *ConfigBuilder) WithPoolWorkersMax(v int) *ConfigBuilder {
+	b.dto.Pool.Workers.Max = v
+
+	return b
+}
+
+// WithPoolIdleValue sets the Pool.Idle.Value value.
+func (b *ConfigBuilder) WithPoolIdleValue(v time.Duration) *ConfigBuilder {
+	b.dto.Pool.Idle.Value = v
+
+	return b
+}
+
+// WithPoolIdleSet sets the Pool.Idle.Set value.
+func (b *ConfigBuilder) WithPoolIdleSet(v bool) *ConfigBuilder {
+	b.dto.Pool.Idle.Set = v
+
+	return b
+}
+
+// WithUpstreamURL sets the Upstream.URL value.
+func (b *ConfigBuilder) WithUpstreamURL(v string) *ConfigBuilder {
+	b.dto.Upstream.URL = v
+
+	return b
+}
+
+// WithUpstreamFallback sets the Upstream.Fallback value.
+func (b *ConfigBuilder) WithUpstreamFallback(v *upstreamConfig) *ConfigBuilder {
+	b.dto.Upstream.Fallback = v
+
+	return b
+}
+
+// WithUpstreamMirrors sets the Upstream.Mirrors value.
+func (b *ConfigBuilder) WithUpstreamMirrors(v []upstreamConfig) *ConfigBuilder {
+	b.dto.Upstream.Mirrors = v
+
+	return b
+}
+
+// WithUpstreamToken sets the Upstream.Token value.
+func (b *ConfigBuilder) WithUpstreamToken(v string) *ConfigBuilder {
+	b.dto.Upstream.Token = v
+
+	return b
+}
+
+// DTO returns a copy of the built source struct.
+func (b *ConfigBuilder) DTO() config {
+	return b.dto
+}
+
+// Build returns the Config built from the source struct.
+func (b *ConfigBuilder) Build() Config {
+	return NewConfig(b.dto)
+}
+
+func configFixtureMust[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func configFixtureText[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](value string) T {
+	var v T
+
+	if err := P(&v).UnmarshalText([]byte(value)); err != nil {
+		panic(err)
+	}
+
+	return v
+}
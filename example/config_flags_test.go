@@ -0,0 +1,99 @@
+package example
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigFlags(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Args     []string
+		Expected func(dto *config)
+	}{
+		{
+			Name:     "no flags passed",
+			Expected: func(*config) {},
+		},
+		{
+			Name: "passed flags override file values",
+			Args: []string{"--api.port=9090", "--api.req_ttl=5m", "--logger.format=json"},
+			Expected: func(dto *config) {
+				dto.API.Port = 9090
+				dto.API.ReqTTL = 5 * time.Minute
+				dto.Logger.Format = LogFormatJSON
+			},
+		},
+		{
+			Name: "passed flag equal to default overrides file value",
+			Args: []string{"--api.port=8080"},
+			Expected: func(dto *config) {
+				dto.API.Port = 8080
+			},
+		},
+		{
+			Name: "passed text unmarshaler slice and url",
+			Args: []string{"--api.trusted_nets=192.168.0.0/16", "--api.public_url=https://example.com"},
+			Expected: func(dto *config) {
+				dto.API.TrustedNets = []netip.Prefix{netip.MustParsePrefix("192.168.0.0/16")}
+				dto.API.PublicURL = mustParseURL(t, "https://example.com")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var flags config
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			RegisterConfigFlags(fs, &flags)
+			require.NoError(t, fs.Parse(test.Args))
+
+			dto := newFileDTO(t)
+			MergeConfigFlags(fs, &dto, flags)
+
+			expected := newFileDTO(t)
+			test.Expected(&expected)
+
+			assert.Equal(t, expected, dto)
+		})
+	}
+}
+
+func TestRegisterConfigFlags_Defaults(t *testing.T) {
+	var flags config
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterConfigFlags(fs, &flags)
+	require.NoError(t, fs.Parse(nil))
+
+	// Flags not passed keep the defaults from the tags in the bound values.
+	assert.Equal(t, 8080, flags.API.Port)
+	assert.Equal(t, time.Hour, flags.API.ReqTTL)
+	assert.Equal(t, "http://localhost:8080", flags.API.PublicURL.String())
+
+	// Secret fields have no flags.
+	assert.Nil(t, fs.Lookup("api.secret"))
+}
+
+// newFileDTO returns the config value as if loaded from the file, differing from the flags defaults.
+func newFileDTO(t *testing.T) config {
+	t.Helper()
+
+	dto := config{}
+	dto.App.Env = "production"
+	dto.Logger.Format = LogFormatText
+	dto.API.Host = "10.0.0.1"
+	dto.API.Port = 443
+	dto.API.Secret = "file-secret"
+	dto.API.ReqTTL = 30 * time.Second
+	dto.API.PublicURL = mustParseURL(t, "https://file.example.com")
+	dto.API.TrustedNets = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	return dto
+}
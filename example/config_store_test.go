@@ -0,0 +1,165 @@
+package example
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigStore_ZeroValue(t *testing.T) {
+	var store ConfigStore
+
+	assert.Equal(t, Config{}, store.Load())
+	assert.Equal(t, Config{}, store.Swap(newPoolConfig(1)))
+	assert.Equal(t, 1, store.Load().Pool().Size())
+}
+
+func TestConfigStore_ConcurrentLoadReload(t *testing.T) {
+	const reloads = 100
+
+	store := NewConfigStore(newPoolConfig(0))
+
+	var (
+		mu    sync.Mutex
+		swaps [][2]int
+	)
+
+	store.Subscribe(func(old, new Config) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		swaps = append(swaps, [2]int{old.Pool().Size(), new.Pool().Size()})
+	})
+
+	wg := sync.WaitGroup{}
+
+	for i := 1; i <= reloads; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, store.Reload(func() (config, error) {
+				return config{Pool: poolConfig{Size: i}}, nil
+			}))
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			size := store.Load().Pool().Size()
+			assert.True(t, size >= 0 && size <= reloads, "unexpected size %d", size)
+		}()
+	}
+
+	wg.Wait()
+
+	// Each swap is notified once, starting from the previous notified value.
+	require.Len(t, swaps, reloads)
+
+	seen := make(map[int]bool, reloads)
+	prev := 0
+
+	for _, swap := range swaps {
+		assert.Equal(t, prev, swap[0])
+		assert.False(t, seen[swap[1]], "size %d is swapped twice", swap[1])
+
+		seen[swap[1]] = true
+		prev = swap[1]
+	}
+
+	assert.Equal(t, prev, store.Load().Pool().Size())
+}
+
+func TestConfigStore_FailedReload(t *testing.T) {
+	store := NewConfigStore(newPoolConfig(1))
+	notified := false
+
+	store.Subscribe(func(_, _ Config) {
+		notified = true
+	})
+
+	errLoad := errors.New("invalid config")
+
+	err := store.Reload(func() (config, error) {
+		return config{Pool: poolConfig{Size: 2}}, errLoad
+	})
+
+	require.ErrorIs(t, err, errLoad)
+	assert.Equal(t, 1, store.Load().Pool().Size())
+	assert.False(t, notified)
+}
+
+func TestConfigStore_SubscribersOrder(t *testing.T) {
+	store := NewConfigStore(newPoolConfig(1))
+
+	var calls []string
+
+	subscribe := func(name string) func() {
+		return store.Subscribe(func(old, new Config) {
+			calls = append(calls, fmt.Sprintf("%s: %d -> %d", name, old.Pool().Size(), new.Pool().Size()))
+		})
+	}
+
+	subscribe("first")
+	unsubscribe := subscribe("second")
+	subscribe("third")
+
+	store.Swap(newPoolConfig(2))
+	assert.Equal(t, []string{"first: 1 -> 2", "second: 1 -> 2", "third: 1 -> 2"}, calls)
+
+	calls = nil
+
+	unsubscribe()
+	unsubscribe()
+
+	store.Swap(newPoolConfig(3))
+	assert.Equal(t, []string{"first: 2 -> 3", "third: 2 -> 3"}, calls)
+}
+
+func TestConfigStore_SubscriberCallsStore(t *testing.T) {
+	store := NewConfigStore(newPoolConfig(1))
+
+	var (
+		loaded      []int
+		unsubscribe func()
+		added       int
+	)
+
+	unsubscribe = store.Subscribe(func(_, _ Config) {
+		loaded = append(loaded, store.Load().Pool().Size())
+
+		// The subscriptions could be changed by the subscriber, changes apply from the next swap.
+		store.Subscribe(func(_, _ Config) {
+			added++
+		})
+		unsubscribe()
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		store.Swap(newPoolConfig(2))
+		store.Swap(newPoolConfig(3))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "subscriber calling the store is deadlocked")
+	}
+
+	assert.Equal(t, []int{2}, loaded)
+	assert.Equal(t, 1, added)
+}
+
+func newPoolConfig(size int) Config {
+	return NewConfig(config{Pool: poolConfig{Size: size}})
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/changelog"
+	"github.com/kukymbr/configen/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+type diffOptions struct {
+	structOptions
+
+	// From is a git revision of the old struct version.
+	From string
+
+	// To is a git revision of the new struct version, empty for the working tree.
+	To string
+
+	// Format is an output format, see changelog.Formats.
+	Format string
+}
+
+func newDiffCommand() *cobra.Command {
+	opt := diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Print config changes between two git revisions",
+		Long: `Loads the config struct at two git revisions and prints the added, removed and renamed keys, ` +
+			`changed types and defaults of the YAML keys and dotenv variables.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// The changes are printed to the stdout, the generator logs would break the output.
+			logger.SetSilentMode(true)
+
+			from, err := opt.keysAt(cmd.Context(), opt.From)
+			if err != nil {
+				return fmt.Errorf("revision %s: %w", opt.From, err)
+			}
+
+			to, err := opt.keysAt(cmd.Context(), opt.To)
+			if err != nil {
+				return fmt.Errorf("revision %s: %w", opt.To, err)
+			}
+
+			return changelog.Write(cmd.OutOrStdout(), changelog.Compare(from, to), opt.Format)
+		},
+		SilenceUsage: true,
+	}
+
+	initStructFlags(cmd, &opt.structOptions)
+
+	cmd.Flags().StringVar(&opt.From, "from", "", "Git revision of the old struct version")
+	cmd.Flags().StringVar(&opt.To, "to", "", "Git revision of the new struct version (default is the working tree)")
+	cmd.Flags().StringVar(
+		&opt.Format,
+		"format", changelog.FormatText,
+		"Output format: "+strings.Join(changelog.Formats, ", "),
+	)
+
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// keysAt returns the config keys of the struct at the git revision, of the working tree if revision is empty.
+func (opt diffOptions) keysAt(ctx context.Context, rev string) ([]changelog.Key, error) {
+	genOpt, err := opt.ToGeneratorOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	if rev != "" {
+		sourceDir, cleanup, err := checkoutRevision(ctx, opt.SourceDir, rev)
+		if err != nil {
+			return nil, err
+		}
+
+		defer cleanup()
+
+		genOpt.SourceDir = sourceDir
+	}
+
+	gen, err := generator.New(genOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	return gen.Keys(ctx)
+}
+
+// checkoutRevision checks out the git revision into a temporary worktree,
+// returns the source dir path in the worktree and the func removing the worktree.
+func checkoutRevision(ctx context.Context, sourceDir string, rev string) (string, func(), error) {
+	absSourceDir, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if absSourceDir, err = filepath.EvalSymlinks(absSourceDir); err != nil {
+		return "", nil, err
+	}
+
+	root, err := git(ctx, absSourceDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, err
+	}
+
+	relSourceDir, err := filepath.Rel(root, absSourceDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "configen-diff-*")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := git(ctx, root, "worktree", "add", "--detach", tmpDir, rev); err != nil {
+		_ = os.RemoveAll(tmpDir)
+
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		// The context may be canceled already, the worktree must be removed anyway.
+		if _, err := git(context.WithoutCancel(ctx), root, "worktree", "remove", "--force", tmpDir); err != nil {
+			logger.Warningf("Failed to remove the git worktree %s: %s", tmpDir, err)
+		}
+
+		_ = os.RemoveAll(tmpDir)
+	}
+
+	return filepath.Join(tmpDir, relSourceDir), cleanup, nil
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -1,9 +1,11 @@
 package command
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/adapter/usertpl"
 	"github.com/kukymbr/configen/internal/generator/gentype"
 )
 
@@ -16,27 +18,23 @@ type options struct {
 	// StructName is a struct name to generate config from.
 	StructName string
 
-	// YAMLPath is a path to a target YAML config file.
-	// Define to enable YAML generator.
-	// Set "true" to enable the generator with a default file path.
-	YAMLPath string
+	// Outputs are the target file paths, keyed by the adapter name.
+	// Define to enable the adapter.
+	// Set "true" to enable the adapter with a default file path.
+	Outputs map[string]*string
 
-	// EnvPath is a path to a target .env config file.
-	// Define to enable Env generator.
-	// Set "true" to enable the generator with a default file path.
-	EnvPath string
+	// AdapterOptions are the values of the options declared by the adapters,
+	// keyed by the adapter name and the option name.
+	AdapterOptions map[string]map[string]*string
 
-	// GoPath is a path to a target Go config getter file.
-	GoPath string
+	// Plugins are the external adapters definitions in the `<name>:<output path>` format.
+	Plugins []string
 
-	// YAMLTag is a tag name for YAML field name, `yaml` by default.
-	YAMLTag string
+	// PluginOptions are the external adapters options in the `<name>:<option>=<value>` format.
+	PluginOptions []string
 
-	// EnvTag is a tag name for a dotenv field name, `env` by default.
-	EnvTag string
-
-	// EnvPrefixTag is a tag name for a dotenv field name prefix.
-	EnvPrefixTag string
+	// Templates are the user templates definitions in the `<template path>:<output path>` format.
+	Templates []string
 
 	// DefaultValueTag is an explicit tag name for a default value.
 	// Overrides the default lookup if given.
@@ -46,51 +44,171 @@ type options struct {
 	// Default is the current directory (most applicable for go:generate).
 	SourceDir string
 
-	// GoTargetStructName is the name of the target struct.
-	GoTargetStructName string
+	// MaxDepth is a max nesting depth of the structs processing.
+	MaxDepth int
+
+	// ScalarTypes are the custom scalar types definitions,
+	// see gentype.ParseScalarType for the format.
+	ScalarTypes []string
+
+	// Prune enables the `prune` option of the adapters declaring it.
+	Prune bool
+
+	// Profiles are the profiles definitions, see parseProfile for the format.
+	Profiles []string
 
-	// GoTargetPackageName is the name of the target golang package.
-	GoTargetPackageName string
+	// ProfileReport is a path of the report listing the values differing between the profiles.
+	ProfileReport string
+
+	// YAMLMultidoc is a path of the YAML stream with one document per profile.
+	YAMLMultidoc string
+
+	// YAMLMultidocKey is a discriminator key of the YAML stream documents.
+	YAMLMultidocKey string
 }
 
-func (opt options) ToGeneratorOptions() generator.Options {
+func (opt options) ToGeneratorOptions(registry *generator.Registry) (generator.Options, error) {
 	gen := generator.Options{
 		StructName: opt.StructName,
 		SourceDir:  opt.SourceDir,
+		MaxDepth:   opt.MaxDepth,
+		Outputs:    make(map[string]gentype.OutputOptions),
+		Registry:   registry,
 	}
 
-	outOpts := []struct {
-		Input     string
-		Tag       string
-		PrefixTag string
-		Target    *gentype.OutputOptions
-	}{
-		{Input: opt.YAMLPath, Tag: opt.YAMLTag, Target: &gen.YAML},
-		{Input: opt.EnvPath, Tag: opt.EnvTag, PrefixTag: opt.EnvPrefixTag, Target: &gen.Env},
-		{Input: opt.GoPath, Target: &gen.GoGetter},
-	}
-
-	for _, out := range outOpts {
-		out.Target.Tag = out.Tag
-		out.Target.PrefixTag = out.PrefixTag
+	for name, input := range opt.Outputs {
+		out := gentype.OutputOptions{
+			DefaultValueTag: opt.DefaultValueTag,
+		}
 
-		keyword := strings.ToLower(out.Input)
+		keyword := strings.ToLower(*input)
 		switch keyword {
-		case keywordTrue:
-			out.Target.Enable = true
-
-			continue
 		case keywordFalse, "":
 			continue
+		case keywordTrue:
+		default:
+			out.Path = *input
+		}
+
+		for _, adapterOpt := range registry.Options(name) {
+			if value := opt.AdapterOptions[name][adapterOpt.Name]; value != nil {
+				adapterOpt.ApplyTo(&out, *value)
+			}
+
+			if opt.Prune && adapterOpt.Name == gentype.ParamPrune {
+				adapterOpt.ApplyTo(&out, keywordTrue)
+			}
+		}
+
+		gen.Outputs[name] = out
+	}
+
+	if err := opt.preparePlugins(&gen); err != nil {
+		return generator.Options{}, err
+	}
+
+	if err := opt.prepareTemplates(&gen); err != nil {
+		return generator.Options{}, err
+	}
+
+	for _, definition := range opt.Profiles {
+		profile, err := parseProfile(definition, registry)
+		if err != nil {
+			return generator.Options{}, err
+		}
+
+		gen.Profiles = append(gen.Profiles, profile)
+	}
+
+	gen.ProfileReport = opt.ProfileReport
+	gen.YAMLMultidoc = opt.YAMLMultidoc
+	gen.YAMLMultidocKey = opt.YAMLMultidocKey
+
+	scalars, err := parseScalarTypes(opt.ScalarTypes)
+	if err != nil {
+		return generator.Options{}, err
+	}
+
+	gen.ScalarTypes = scalars
+
+	return gen, nil
+}
+
+func parseScalarTypes(definitions []string) ([]gentype.ScalarType, error) {
+	scalars := make([]gentype.ScalarType, 0, len(definitions))
+
+	for _, definition := range definitions {
+		scalar, err := gentype.ParseScalarType(definition)
+		if err != nil {
+			return nil, err
 		}
 
-		out.Target.Enable = true
-		out.Target.Path = out.Input
-		out.Target.DefaultValueTag = opt.DefaultValueTag
+		scalars = append(scalars, scalar)
+	}
+
+	return scalars, nil
+}
+
+func (opt options) preparePlugins(gen *generator.Options) error {
+	for _, definition := range opt.Plugins {
+		name, path, ok := strings.Cut(definition, ":")
+		if !ok || name == "" || path == "" {
+			return fmt.Errorf("invalid plugin definition %q, expected `<name>:<output path>`", definition)
+		}
+
+		if err := gen.Registry.RegisterPlugin(name, ""); err != nil {
+			return err
+		}
+
+		gen.Outputs[name] = gentype.OutputOptions{
+			Path:            path,
+			DefaultValueTag: opt.DefaultValueTag,
+		}
 	}
 
-	gen.GoGetter.TargetStructName = opt.GoTargetStructName
-	gen.GoGetter.TargetPackageName = opt.GoTargetPackageName
+	for _, definition := range opt.PluginOptions {
+		name, param, _ := strings.Cut(definition, ":")
+		key, value, ok := strings.Cut(param, "=")
+
+		out, isDefined := gen.Outputs[name]
+		if !ok || key == "" || !isDefined {
+			return fmt.Errorf("invalid plugin option %q, expected `<name>:<option>=<value>` of the defined plugin", definition)
+		}
+
+		gentype.AdapterOption{Name: key}.ApplyTo(&out, value)
+		gen.Outputs[name] = out
+	}
+
+	return nil
+}
+
+func (opt options) prepareTemplates(gen *generator.Options) error {
+	for _, definition := range opt.Templates {
+		tplPath, path, ok := strings.Cut(definition, ":")
+		if !ok || tplPath == "" || path == "" {
+			return fmt.Errorf("invalid template definition %q, expected `<template path>:<output path>`", definition)
+		}
+
+		gen.Templates = append(gen.Templates, gentype.OutputOptions{
+			Path:            path,
+			TemplatePath:    tplPath,
+			Tag:             opt.adapterOption(generator.AdapterYAML, "tag"),
+			PrefixTag:       opt.adapterOption(generator.AdapterEnv, "prefix-tag"),
+			DefaultValueTag: opt.DefaultValueTag,
+			Params: map[string]string{
+				usertpl.ParamEnvTag: opt.adapterOption(generator.AdapterEnv, "tag"),
+			},
+		})
+	}
+
+	return nil
+}
+
+// adapterOption returns the value of the adapter option flag, empty string if not defined.
+func (opt options) adapterOption(adapter string, name string) string {
+	if value := opt.AdapterOptions[adapter][name]; value != nil {
+		return *value
+	}
 
-	return gen
+	return ""
 }
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// parseProfile parses the profile definition
+// in the `<name>:[<setting>=<value>,...]:<adapter>=<output path>,...` format.
+// Settings are the `tag` (YAML tag), `value` (value tag), `env-tag` and `env-prefix-tag`
+// and the `<adapter>-<option>` options of the profile outputs.
+// Outputs may be empty for the profiles used in the multi-document YAML only, e.g. `local:tag=local:`.
+func parseProfile(definition string, registry *generator.Registry) (generator.Profile, error) {
+	parts := strings.SplitN(definition, ":", 3)
+	if len(parts) == 2 {
+		parts = []string{parts[0], "", parts[1]}
+	}
+
+	if len(parts) != 3 || parts[0] == "" {
+		return generator.Profile{}, fmt.Errorf(
+			"invalid profile definition %q, expected `<name>:[<setting>=<value>,...]:<adapter>=<output path>,...`",
+			definition,
+		)
+	}
+
+	profile := generator.Profile{
+		Name:    parts[0],
+		Outputs: make(map[string]gentype.OutputOptions),
+	}
+
+	outputs, err := parseProfilePairs(parts[2])
+	if err != nil {
+		return generator.Profile{}, fmt.Errorf("profile %s outputs: %w", profile.Name, err)
+	}
+
+	for name, path := range outputs {
+		profile.Outputs[name] = gentype.OutputOptions{Path: path}
+	}
+
+	settings, err := parseProfilePairs(parts[1])
+	if err != nil {
+		return generator.Profile{}, fmt.Errorf("profile %s settings: %w", profile.Name, err)
+	}
+
+	for key, value := range settings {
+		switch key {
+		case "tag", generator.AdapterYAML + "-tag":
+			profile.YAMLTag = value
+		case "value", "value-tag":
+			profile.DefaultValueTag = value
+		case generator.AdapterEnv + "-tag":
+			profile.EnvTag = value
+		case generator.AdapterEnv + "-prefix-tag":
+			profile.EnvPrefixTag = value
+		default:
+			if err := applyProfileOption(&profile, registry, key, value); err != nil {
+				return generator.Profile{}, err
+			}
+		}
+	}
+
+	return profile, nil
+}
+
+// applyProfileOption applies the `<adapter>-<option>` setting to the profile output of the adapter.
+func applyProfileOption(profile *generator.Profile, registry *generator.Registry, key string, value string) error {
+	for name, out := range profile.Outputs {
+		for _, adapterOpt := range registry.Options(name) {
+			if key != name+"-"+adapterOpt.Name {
+				continue
+			}
+
+			adapterOpt.ApplyTo(&out, value)
+			profile.Outputs[name] = out
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("profile %s: unknown setting %s", profile.Name, key)
+}
+
+// parseProfilePairs parses the comma-separated `<key>=<value>` pairs.
+func parseProfilePairs(definition string) (map[string]string, error) {
+	pairs := make(map[string]string)
+
+	if definition == "" {
+		return pairs, nil
+	}
+
+	for _, pair := range strings.Split(definition, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected `<key>=<value>`", pair)
+		}
+
+		if _, exists := pairs[key]; exists {
+			return nil, fmt.Errorf("%s is defined twice", key)
+		}
+
+		pairs[key] = value
+	}
+
+	return pairs, nil
+}
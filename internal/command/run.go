@@ -2,9 +2,8 @@ package command
 
 import (
 	"context"
-	"os"
-	"os/signal"
-	"syscall"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/kukymbr/configen/internal/generator"
@@ -19,26 +18,36 @@ func Run() error {
 
 	opt := options{}
 	silent := false
+	registry := generator.NewDefaultRegistry()
 
 	var cmd = &cobra.Command{
 		Use:   "configen",
 		Short: "Configs generator",
 		Long:  `The go:generate tool to generate YAML and dotenv configuration files from the Golang struct.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-			defer cancel()
+			genOpt, err := opt.ToGeneratorOptions(registry)
+			if err != nil {
+				return err
+			}
 
-			gen, err := generator.New(opt.ToGeneratorOptions())
+			gen, err := generator.New(genOpt)
 			if err != nil {
 				return err
 			}
 
-			return gen.Generate(ctx)
+			return gen.Generate(cmd.Context())
 		},
 		Version: version.GetVersion(),
 	}
 
-	initFlags(cmd, &opt, &silent)
+	cmd.PersistentFlags().BoolVarP(&silent, "silent", "s", false, "Silent mode")
+
+	initFlags(cmd, &opt)
+	initAdapterFlags(cmd, &opt, registry)
+
+	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newWatchCommand(registry))
 
 	cmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
 		logger.SetSilentMode(silent)
@@ -48,9 +57,7 @@ func Run() error {
 }
 
 //nolint:funlen
-func initFlags(cmd *cobra.Command, opt *options, silent *bool) {
-	cmd.PersistentFlags().BoolVarP(silent, "silent", "s", false, "Silent mode")
-
+func initFlags(cmd *cobra.Command, opt *options) {
 	cmd.Flags().StringVar(
 		&opt.StructName,
 		"struct", "",
@@ -58,69 +65,145 @@ func initFlags(cmd *cobra.Command, opt *options, silent *bool) {
 	)
 
 	cmd.Flags().StringVar(
-		&opt.YAMLPath,
-		"yaml", "",
-		"Path to YAML config file, set 'true' to enable with default path",
+		&opt.DefaultValueTag,
+		"value-tag", "",
+		"Tag name for a default value, prepends the default lookup if given",
 	)
 
 	cmd.Flags().StringVar(
-		&opt.EnvPath,
-		"env", "",
-		"Path to dotenv config file, set 'true' to enable with default path",
+		&opt.SourceDir,
+		"source", generator.DefaultSourceDir,
+		"Directory of the source go files",
 	)
 
-	cmd.Flags().StringVar(
-		&opt.GoPath,
-		"go", "",
-		"Path to Golang config getter file, set 'true' to enable with default path",
+	cmd.Flags().IntVar(
+		&opt.MaxDepth,
+		"max-depth", generator.DefaultMaxDepth,
+		"Max nesting depth of the structs",
 	)
 
-	cmd.Flags().StringVar(
-		&opt.YAMLTag,
-		"yaml-tag", generator.DefaultYAMLTag,
-		"Tag name for a YAML field names",
+	cmd.Flags().StringArrayVar(
+		&opt.ScalarTypes,
+		"scalar-type", nil,
+		"Custom type to render as a single value, in '<[pkg/path.]Type>:<string|integer|number|boolean>[:<sample>]' format",
 	)
 
-	cmd.Flags().StringVar(
-		&opt.EnvTag,
-		"env-tag", generator.DefaultEnvTag,
-		"Tag name for a dotenv variables names",
+	cmd.Flags().StringArrayVar(
+		&opt.Plugins,
+		"plugin", nil,
+		"External adapter in '<name>:<output path>' format, runs the 'configen-gen-<name>' executable from the PATH",
 	)
 
-	cmd.Flags().StringVar(
-		&opt.EnvPrefixTag,
-		"env-prefix-tag", generator.DefaultEnvPrefixTag,
-		"Tag name for a dotenv variable prefixes",
+	cmd.Flags().StringArrayVar(
+		&opt.PluginOptions,
+		"plugin-opt", nil,
+		"External adapter option in '<name>:<option>=<value>' format",
 	)
 
-	cmd.Flags().StringVar(
-		&opt.GoTargetStructName,
-		"go-struct", "",
-		"Target struct name (default is exported variant of incoming struct name)",
+	cmd.Flags().StringArrayVar(
+		&opt.Templates,
+		"template", nil,
+		"User text/template to render in '<template path>:<output path>' format",
+	)
+
+	cmd.Flags().BoolVar(
+		&opt.Prune,
+		"prune", false,
+		"Remove the values missing in the struct from the merged files, same as --<adapter>-prune for each adapter",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&opt.Profiles,
+		"profile", nil,
+		"Outputs variant in '<name>:[tag=<tag>,value=<value tag>,...]:<adapter>=<output path>,...' format",
 	)
 
 	cmd.Flags().StringVar(
-		&opt.GoTargetPackageName,
-		"go-pkg", "",
-		"Target package name",
+		&opt.ProfileReport,
+		"profile-report", "",
+		"Path to Markdown report of the values differing between the profiles",
 	)
 
 	cmd.Flags().StringVar(
-		&opt.DefaultValueTag,
-		"value-tag", "",
-		"Tag name for a default value, prepends the default lookup if given",
+		&opt.YAMLMultidoc,
+		"yaml-multidoc", "",
+		"Path to YAML stream with one '---'-separated document per profile",
 	)
 
 	cmd.Flags().StringVar(
-		&opt.SourceDir,
-		"source", generator.DefaultSourceDir,
-		"Directory of the source go files",
+		&opt.YAMLMultidocKey,
+		"yaml-multidoc-key", "",
+		"Discriminator key with the profile name to add to each document of the YAML stream, e.g. 'environment'",
 	)
 
 	_ = cmd.MarkFlagRequired("struct")
-	cmd.MarkFlagsOneRequired("yaml", "env", "go")
-	_ = cmd.MarkFlagFilename("yaml")
-	_ = cmd.MarkFlagFilename("env")
-	_ = cmd.MarkFlagFilename("go")
 	_ = cmd.MarkFlagDirname("source")
+	_ = cmd.MarkFlagFilename("profile-report", "md")
+	_ = cmd.MarkFlagFilename("yaml-multidoc", "yaml", "yml")
+}
+
+// initAdapterFlags adds the `--<adapter>` and `--<adapter>-<option>` flags of the registered adapters.
+func initAdapterFlags(cmd *cobra.Command, opt *options, registry *generator.Registry) {
+	names := registry.Names()
+
+	opt.Outputs = make(map[string]*string, len(names))
+	opt.AdapterOptions = make(map[string]map[string]*string, len(names))
+
+	for _, name := range names {
+		opt.Outputs[name] = cmd.Flags().String(
+			name, "",
+			fmt.Sprintf("Path to %s output file, set 'true' to enable with default path", name),
+		)
+
+		_ = cmd.MarkFlagFilename(name)
+
+		opt.AdapterOptions[name] = make(map[string]*string)
+
+		for _, adapterOpt := range registry.Options(name) {
+			flagName := name + "-" + adapterOpt.Name
+
+			if !adapterOpt.Bool {
+				opt.AdapterOptions[name][adapterOpt.Name] = cmd.Flags().String(
+					flagName, adapterOpt.Default, adapterOpt.Usage,
+				)
+
+				continue
+			}
+
+			value := adapterOpt.Default
+			opt.AdapterOptions[name][adapterOpt.Name] = &value
+
+			cmd.Flags().Var(boolOptionValue{value: &value}, flagName, adapterOpt.Usage)
+			cmd.Flags().Lookup(flagName).NoOptDefVal = keywordTrue
+		}
+	}
+
+	cmd.MarkFlagsOneRequired(append(names, "plugin", "template", "profile", "yaml-multidoc")...)
+}
+
+// boolOptionValue is a flag value of the boolean adapter option, stored as a string.
+type boolOptionValue struct {
+	value *string
+}
+
+func (v boolOptionValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+
+	return *v.value
+}
+
+func (v boolOptionValue) Set(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("invalid boolean value %q", value)
+	}
+
+	*v.value = value
+
+	return nil
+}
+
+func (v boolOptionValue) Type() string {
+	return "bool"
 }
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitAdapterFlags_BoolOptions(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Args     []string
+		Expected string
+		Error    string
+	}{
+		{Name: "not set", Args: nil, Expected: "false"},
+		{Name: "bare flag", Args: []string{"--yaml-merge"}, Expected: "true"},
+		{Name: "bare flag before argument", Args: []string{"--yaml-merge", "--yaml=local.yaml"}, Expected: "true"},
+		{Name: "explicit true", Args: []string{"--yaml-merge=true"}, Expected: "true"},
+		{Name: "explicit false", Args: []string{"--yaml-merge=false"}, Expected: "false"},
+		{Name: "invalid value", Args: []string{"--yaml-merge=maybe"}, Error: `invalid boolean value "maybe"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			opt := options{}
+			cmd := newTestCommand(&opt)
+
+			err := cmd.ParseFlags(test.Args)
+			if test.Error != "" {
+				require.ErrorContains(t, err, test.Error)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, *opt.AdapterOptions[generator.AdapterYAML][gentype.ParamMerge])
+		})
+	}
+}
+
+func TestInitAdapterFlags_StringOptions(t *testing.T) {
+	opt := options{}
+	cmd := newTestCommand(&opt)
+
+	// String options still require the value.
+	require.Error(t, cmd.ParseFlags([]string{"--yaml-tag"}))
+	require.NoError(t, cmd.ParseFlags([]string{"--yaml-tag", "local"}))
+	assert.Equal(t, "local", *opt.AdapterOptions[generator.AdapterYAML]["tag"])
+}
+
+func newTestCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{}
+
+	initFlags(cmd, opt)
+	initAdapterFlags(cmd, opt, generator.NewDefaultRegistry())
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/spf13/cobra"
+)
+
+// structOptions are the source struct options of the commands inspecting the struct without generation.
+type structOptions struct {
+	// StructName is a name of the config struct.
+	StructName string
+
+	// SourceDir is a directory of the source go files.
+	SourceDir string
+
+	// YAMLTag is a tag name for the YAML keys.
+	YAMLTag string
+
+	// EnvTag is a tag name for the dotenv variables names.
+	EnvTag string
+
+	// EnvPrefixTag is a tag name for the dotenv sub-structs variables prefixes.
+	EnvPrefixTag string
+
+	// MaxDepth is a max nesting depth of the structs processing.
+	MaxDepth int
+
+	// ScalarTypes are the custom scalar types definitions.
+	ScalarTypes []string
+}
+
+func (opt structOptions) ToGeneratorOptions() (generator.Options, error) {
+	scalars, err := parseScalarTypes(opt.ScalarTypes)
+	if err != nil {
+		return generator.Options{}, err
+	}
+
+	return generator.Options{
+		StructName:  opt.StructName,
+		SourceDir:   opt.SourceDir,
+		MaxDepth:    opt.MaxDepth,
+		ScalarTypes: scalars,
+		YAML: gentype.OutputOptions{
+			Tag: opt.YAMLTag,
+		},
+		Env: gentype.OutputOptions{
+			Tag:       opt.EnvTag,
+			PrefixTag: opt.EnvPrefixTag,
+		},
+	}, nil
+}
+
+func initStructFlags(cmd *cobra.Command, opt *structOptions) {
+	cmd.Flags().StringVar(&opt.StructName, "struct", "", "Name of the config struct")
+	cmd.Flags().StringVar(&opt.SourceDir, "source", generator.DefaultSourceDir, "Directory of the source go files")
+	cmd.Flags().StringVar(&opt.YAMLTag, "yaml-tag", generator.DefaultYAMLTag, "Tag name for a YAML field names")
+	cmd.Flags().StringVar(&opt.EnvTag, "env-tag", generator.DefaultEnvTag, "Tag name for a dotenv variables names")
+	cmd.Flags().StringVar(
+		&opt.EnvPrefixTag,
+		"env-prefix-tag", generator.DefaultEnvPrefixTag,
+		"Tag name for a dotenv variable prefixes",
+	)
+	cmd.Flags().IntVar(&opt.MaxDepth, "max-depth", generator.DefaultMaxDepth, "Max nesting depth of the structs")
+	cmd.Flags().StringArrayVar(
+		&opt.ScalarTypes,
+		"scalar-type", nil,
+		"Custom type to render as a single value, in '<[pkg/path.]Type>:<string|integer|number|boolean>[:<sample>]' format",
+	)
+
+	_ = cmd.MarkFlagRequired("struct")
+	_ = cmd.MarkFlagDirname("source")
+}
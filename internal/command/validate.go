@@ -0,0 +1,83 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+type validateOptions struct {
+	structOptions
+
+	// Files are the YAML config files paths.
+	Files []string
+
+	// EnvFiles are the dotenv config files paths.
+	EnvFiles []string
+}
+
+func newValidateCommand() *cobra.Command {
+	opt := validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate config files against the struct",
+		Long: `Checks the YAML and dotenv config files against the Golang struct: ` +
+			`unknown and missing required keys, type mismatches and values not in the enum.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			genOpt, err := opt.ToGeneratorOptions()
+			if err != nil {
+				return err
+			}
+
+			gen, err := generator.New(genOpt)
+			if err != nil {
+				return err
+			}
+
+			issues, err := gen.Validate(cmd.Context(), generator.ValidateFiles{
+				YAML: opt.Files,
+				Env:  opt.EnvFiles,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range issues {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), issue.String())
+			}
+
+			if len(issues) > 0 {
+				return errors.New(pluralize(len(issues), "issue") + " found")
+			}
+
+			logger.Successf("Config files are valid.")
+
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	initStructFlags(cmd, &opt.structOptions)
+
+	cmd.Flags().StringArrayVar(&opt.Files, "file", nil, "YAML config file to validate")
+	cmd.Flags().StringArrayVar(&opt.EnvFiles, "env-file", nil, "Dotenv config file to validate")
+
+	_ = cmd.MarkFlagFilename("file", "yaml", "yml")
+	_ = cmd.MarkFlagFilename("env-file")
+
+	cmd.MarkFlagsOneRequired("file", "env-file")
+
+	return cmd
+}
+
+func pluralize(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,221 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+const defaultDebounce = 300 * time.Millisecond
+
+type watchOptions struct {
+	options
+
+	// Debounce is a delay after the last source change before the regeneration.
+	Debounce time.Duration
+}
+
+func newWatchCommand(registry *generator.Registry) *cobra.Command {
+	opt := watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate the outputs on the source struct changes",
+		Long: `Watches the Go files of the source package and reruns the generation on change. ` +
+			`Accepts the same generation flags as the root command.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			genOpt, err := opt.ToGeneratorOptions(registry)
+			if err != nil {
+				return err
+			}
+
+			gen, err := generator.New(genOpt)
+			if err != nil {
+				return err
+			}
+
+			// The generator logs are replaced with a line per generation.
+			logger.SetSilentMode(true)
+
+			// The watch runs until interrupted, without the time limit of the other commands.
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return watch(ctx, gen, opt.Debounce, cmd.OutOrStdout())
+		},
+		SilenceUsage: true,
+	}
+
+	initFlags(cmd, &opt.options)
+	initAdapterFlags(cmd, &opt.options, registry)
+
+	cmd.Flags().DurationVar(
+		&opt.Debounce,
+		"debounce", defaultDebounce,
+		"Delay after the last source change before the regeneration",
+	)
+
+	return cmd
+}
+
+// watchGenerator is a generator of the watched outputs, implemented by the generator.Generator.
+type watchGenerator interface {
+	SourceFiles() ([]string, error)
+	OutputPaths() []string
+	Write(ctx context.Context) ([]generator.WrittenFile, error)
+}
+
+// sourceWatcher tracks the source package Go files, ignoring the generated outputs placed next to them.
+type sourceWatcher struct {
+	gen      watchGenerator
+	add      func(path string) error
+	debounce time.Duration
+	out      io.Writer
+	sources  map[string]bool
+	outputs  map[string]bool
+}
+
+// watch generates the outputs and regenerates them on the source files changes until ctx is done.
+func watch(ctx context.Context, gen *generator.Generator, debounce time.Duration, out io.Writer) error {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+
+	defer func() { _ = fs.Close() }()
+
+	return newSourceWatcher(gen, fs.Add, debounce, out).run(ctx, fs.Events, fs.Errors)
+}
+
+// newSourceWatcher creates the sourceWatcher, add is called to watch the source directories.
+func newSourceWatcher(gen watchGenerator, add func(path string) error, debounce time.Duration, out io.Writer) *sourceWatcher {
+	w := &sourceWatcher{
+		gen:      gen,
+		add:      add,
+		debounce: debounce,
+		out:      out,
+		outputs:  make(map[string]bool),
+	}
+
+	for _, path := range gen.OutputPaths() {
+		if abs, err := filepath.Abs(path); err == nil {
+			w.outputs[abs] = true
+		}
+	}
+
+	return w
+}
+
+// run generates the outputs and regenerates them on the source changes events until ctx is done
+// or the events channel is closed. The generation errors are logged and don't stop the watching.
+func (w *sourceWatcher) run(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error) error {
+	if err := w.refresh(); err != nil {
+		return err
+	}
+
+	w.generate(ctx)
+
+	_, _ = fmt.Fprintf(w.out, "Watching %s, press Ctrl+C to stop\n", pluralize(len(w.sources), "source file"))
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if w.isSourceChange(event) {
+				timer.Reset(w.debounce)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+
+			logger.Errorf("Watch: %s", err)
+		case <-timer.C:
+			w.generate(ctx)
+
+			if err := w.refresh(); err != nil {
+				logger.Errorf("Watch: %s", err)
+			}
+		}
+	}
+}
+
+// refresh reloads the source files list and watches their directories,
+// the directories are watched instead of the files to survive the editors replacing the files on save.
+func (w *sourceWatcher) refresh() error {
+	files, err := w.gen.SourceFiles()
+	if err != nil {
+		return err
+	}
+
+	w.sources = make(map[string]bool, len(files))
+
+	for _, file := range files {
+		w.sources[filepath.Clean(file)] = true
+
+		if err := w.add(filepath.Dir(file)); err != nil {
+			return fmt.Errorf("watch %s: %w", filepath.Dir(file), err)
+		}
+	}
+
+	return nil
+}
+
+// isSourceChange checks if event changes the source file or creates a new Go file in the package.
+func (w *sourceWatcher) isSourceChange(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write | fsnotify.Create | fsnotify.Remove | fsnotify.Rename) {
+		return false
+	}
+
+	name := filepath.Clean(event.Name)
+
+	if w.outputs[name] {
+		return false
+	}
+
+	if w.sources[name] {
+		return true
+	}
+
+	return event.Has(fsnotify.Create) && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+func (w *sourceWatcher) generate(ctx context.Context) {
+	start := time.Now()
+
+	files, err := w.gen.Write(ctx)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return
+	case err != nil:
+		logger.Errorf("[%s] %s", start.Format(time.TimeOnly), err)
+	default:
+		_, _ = fmt.Fprintf(
+			w.out, "[%s] Generated %s in %s\n",
+			start.Format(time.TimeOnly),
+			generator.SummarizeFiles(files),
+			time.Since(start).Round(time.Millisecond),
+		)
+	}
+}
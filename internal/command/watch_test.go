@@ -0,0 +1,212 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDebounce = 20 * time.Millisecond
+
+func TestSourceWatcher_IsSourceChange(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "config.go")
+	output := filepath.Join(dir, "config.gen.go")
+
+	w := newSourceWatcher(newFakeGenerator(source, output), func(string) error { return nil }, testDebounce, io.Discard)
+	require.NoError(t, w.refresh())
+
+	tests := []struct {
+		Name     string
+		Event    fsnotify.Event
+		Expected bool
+	}{
+		{Name: "source written", Event: fsnotify.Event{Name: source, Op: fsnotify.Write}, Expected: true},
+		{Name: "source removed", Event: fsnotify.Event{Name: source, Op: fsnotify.Remove}, Expected: true},
+		{Name: "source renamed", Event: fsnotify.Event{Name: source, Op: fsnotify.Rename}, Expected: true},
+		{Name: "source chmod", Event: fsnotify.Event{Name: source, Op: fsnotify.Chmod}, Expected: false},
+		{Name: "output written", Event: fsnotify.Event{Name: output, Op: fsnotify.Write}, Expected: false},
+		{Name: "output created", Event: fsnotify.Event{Name: output, Op: fsnotify.Create}, Expected: false},
+		{Name: "go file created", Event: fsnotify.Event{Name: filepath.Join(dir, "new.go"), Op: fsnotify.Create}, Expected: true},
+		{Name: "test file created", Event: fsnotify.Event{Name: filepath.Join(dir, "new_test.go"), Op: fsnotify.Create}, Expected: false},
+		{Name: "other file created", Event: fsnotify.Event{Name: filepath.Join(dir, "config.yaml"), Op: fsnotify.Create}, Expected: false},
+		{Name: "unknown go file written", Event: fsnotify.Event{Name: filepath.Join(dir, "other.go"), Op: fsnotify.Write}, Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, w.isSourceChange(test.Event))
+		})
+	}
+}
+
+func TestSourceWatcher_Debounce(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "config.go")
+	gen := newFakeGenerator(source)
+	events := runWatcher(t, gen, func(string) error { return nil })
+
+	for range 5 {
+		events <- fsnotify.Event{Name: source, Op: fsnotify.Write}
+	}
+
+	assert.Equal(t, 2, gen.waitWrite(t))
+	gen.assertNoWrite(t)
+}
+
+func TestSourceWatcher_RefreshAfterRename(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "config.go")
+	renamed := filepath.Join(dir, "settings.go")
+	gen := newFakeGenerator(source)
+
+	var (
+		mu    sync.Mutex
+		added []string
+	)
+
+	events := runWatcher(t, gen, func(path string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		added = append(added, path)
+
+		return nil
+	})
+
+	gen.setSources(renamed)
+	events <- fsnotify.Event{Name: source, Op: fsnotify.Rename}
+	assert.Equal(t, 2, gen.waitWrite(t))
+
+	// The directory is added again, as it could be replaced by the rename.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Equal([]string{dir, dir}, added)
+	}, time.Second, testDebounce)
+
+	// The renamed file is tracked as a source.
+	events <- fsnotify.Event{Name: renamed, Op: fsnotify.Write}
+	assert.Equal(t, 3, gen.waitWrite(t))
+}
+
+func TestSourceWatcher_FailedGeneration(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "config.go")
+	gen := newFakeGenerator(source)
+	gen.failWrite = 2
+	events := runWatcher(t, gen, func(string) error { return nil })
+
+	events <- fsnotify.Event{Name: source, Op: fsnotify.Write}
+	assert.Equal(t, 2, gen.waitWrite(t))
+
+	events <- fsnotify.Event{Name: source, Op: fsnotify.Write}
+	assert.Equal(t, 3, gen.waitWrite(t))
+}
+
+// runWatcher runs the sourceWatcher until the test end and waits for the initial generation.
+func runWatcher(t *testing.T, gen *fakeGenerator, add func(path string) error) chan<- fsnotify.Event {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	events := make(chan fsnotify.Event)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- newSourceWatcher(gen, add, testDebounce, io.Discard).run(ctx, events, make(chan error))
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	require.Equal(t, 1, gen.waitWrite(t))
+
+	return events
+}
+
+type fakeGenerator struct {
+	mu      sync.Mutex
+	sources []string
+	outputs []string
+	calls   int
+
+	// failWrite is a number of the failing Write call, 0 if all calls succeed.
+	failWrite int
+	writes    chan int
+}
+
+func newFakeGenerator(source string, outputs ...string) *fakeGenerator {
+	return &fakeGenerator{
+		sources: []string{source},
+		outputs: outputs,
+		writes:  make(chan int, 10),
+	}
+}
+
+func (g *fakeGenerator) SourceFiles() ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return slices.Clone(g.sources), nil
+}
+
+func (g *fakeGenerator) OutputPaths() []string {
+	return g.outputs
+}
+
+func (g *fakeGenerator) Write(context.Context) ([]generator.WrittenFile, error) {
+	g.mu.Lock()
+	g.calls++
+	call := g.calls
+	g.mu.Unlock()
+
+	defer func() { g.writes <- call }()
+
+	if call == g.failWrite {
+		return nil, errors.New("generation failed")
+	}
+
+	return []generator.WrittenFile{{Path: "config.yaml", Status: generator.FileUpdated}}, nil
+}
+
+func (g *fakeGenerator) setSources(sources ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sources = sources
+}
+
+// waitWrite returns the number of the next Write call.
+func (g *fakeGenerator) waitWrite(t *testing.T) int {
+	t.Helper()
+
+	select {
+	case call := <-g.writes:
+		return call
+	case <-time.After(time.Second):
+		require.FailNow(t, "generation is not started")
+
+		return 0
+	}
+}
+
+func (g *fakeGenerator) assertNoWrite(t *testing.T) {
+	t.Helper()
+
+	select {
+	case call := <-g.writes:
+		assert.Fail(t, "unexpected generation", "call %d", call)
+	case <-time.After(10 * testDebounce):
+	}
+}
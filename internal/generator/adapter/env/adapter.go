@@ -11,21 +11,90 @@ type Env struct {
 	gentype.GenericAdapter
 
 	envs []string
+
+	// vars are the generated variables, in order of appearance.
+	vars []envVar
+	// recursivePrefixes are the prefixes of the omitted recursive structs variables.
+	recursivePrefixes []string
 }
 
-func New(sourceStruct gentype.Source, outputOptions gentype.OutputOptions) *Env {
+// envVar is a generated variable with its comment lines: envs[start:end+1],
+// or a comment line without a variable if name is empty, e.g. the omitted recursive struct note.
+type envVar struct {
+	name  string
+	start int
+	end   int
+
+	// aliases are the old names of the variable with the prefix.
+	aliases []string
+}
+
+func New(model *gentype.Model, outputOptions gentype.OutputOptions) *Env {
 	return &Env{
-		GenericAdapter: gentype.GenericAdapter{
-			Source:        sourceStruct,
-			OutputOptions: outputOptions,
-		},
+		GenericAdapter: gentype.NewGenericAdapter(model, outputOptions),
 
 		envs: make([]string, 0),
 	}
 }
 
+// Options returns the options declared by the dotenv adapter.
+func Options() []gentype.AdapterOption {
+	return []gentype.AdapterOption{
+		{
+			Name:    "tag",
+			Usage:   "Tag name for a dotenv variables names",
+			Default: gentype.TagEnv,
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.Tag = value
+			},
+		},
+		{
+			Name:    "prefix-tag",
+			Usage:   "Tag name for a dotenv variable prefixes",
+			Default: gentype.TagEnvPrefix,
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.PrefixTag = value
+			},
+		},
+		{
+			Name:    gentype.ParamMerge,
+			Usage:   "Merge the generated variables into the existing dotenv file, keeping the user values and lines",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    gentype.ParamPrune,
+			Usage:   "Remove the variables missing in the struct from the merged dotenv file instead of marking them deprecated",
+			Default: "false",
+			Bool:    true,
+		},
+	}
+}
+
 func (g *Env) Generate(ctx context.Context) (gentype.OutputFiles, error) {
-	g.collectEnvVars(ctx, g.Source.Struct, "")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := g.collectEnvVars(g.Model.Root, ""); err != nil {
+		return nil, err
+	}
+
+	merge, err := g.OutputOptions.BoolParam(gentype.ParamMerge)
+	if err != nil {
+		return nil, err
+	}
+
+	if merge {
+		merged, err := g.mergeExisting()
+		if err != nil {
+			return nil, err
+		}
+
+		if merged != nil {
+			return gentype.OutputFiles{merged}, nil
+		}
+	}
 
 	doc := gentype.GetDocComment("#", g.Source.RootStructName, g.Source.RootStructDoc)
 
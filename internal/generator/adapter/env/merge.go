@@ -0,0 +1,227 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// deprecatedComment marks the variables of the merged file missing in the struct.
+const deprecatedComment = "# DEPRECATED: not in struct"
+
+// renamedComment marks the variables of the merged file set by the old name from the `envAliases` tag.
+const renamedComment = "# DEPRECATED: renamed to "
+
+// mergeExisting merges the generated variables into the existing target file:
+// missing variables are added with the defaults and comments into their sections, as well as the generated comment lines,
+// user values and unknown lines are kept,
+// variables missing in the struct are marked as deprecated or removed if prune is enabled.
+// Variables set by their aliases are marked as renamed and kept with the user values.
+// Returns nil if target file does not exist or is empty.
+func (g *Env) mergeExisting() ([]byte, error) {
+	content, err := os.ReadFile(g.OutputOptions.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", g.OutputOptions.Path, err)
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, nil
+	}
+
+	prune, err := g.OutputOptions.BoolParam(gentype.ParamPrune)
+	if err != nil {
+		return nil, err
+	}
+
+	// known are the names of the variables and their aliases, mapped to the current names.
+	known := make(map[string]string, len(g.vars))
+
+	for _, v := range g.vars {
+		if v.name != "" {
+			known[v.name] = v.name
+		}
+
+		for _, alias := range v.aliases {
+			known[alias] = v.name
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	lines = g.markUnknown(lines, known, prune)
+	lines = g.insertMissing(lines)
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// markUnknown marks the variables missing in the struct as deprecated or removes them if prune is enabled,
+// the aliases are marked as renamed. The deprecation marks of the known variables are removed.
+func (g *Env) markUnknown(lines []string, known map[string]string, prune bool) []string {
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		name := parseVarName(line)
+		if name == "" || g.isRecursive(name) {
+			out = append(out, line)
+
+			continue
+		}
+
+		// The previous mark is replaced, the variable could be renamed or returned to the struct.
+		if len(out) > 0 && (out[len(out)-1] == deprecatedComment || strings.HasPrefix(out[len(out)-1], renamedComment)) {
+			out = out[:len(out)-1]
+		}
+
+		current, ok := known[name]
+
+		switch {
+		case !ok && prune:
+			continue
+		case !ok:
+			out = append(out, deprecatedComment)
+		case current != name:
+			out = append(out, renamedComment+current)
+		}
+
+		out = append(out, line)
+	}
+
+	return out
+}
+
+// insertMissing inserts the variables missing in the file before the following variable of the same section
+// or after the preceding variable of the struct.
+// Variables starting a new section are inserted after the section of the preceding variable.
+func (g *Env) insertMissing(lines []string) []string {
+	prev := -1
+
+	for i, v := range g.vars {
+		if pos := g.lineIndex(lines, v); pos >= 0 {
+			prev = pos
+
+			continue
+		}
+
+		block := slices.Clone(g.envs[v.start : v.end+1])
+		pos := prev + 1
+
+		switch next := g.nextInSection(lines, i); {
+		case next >= 0:
+			pos = blockStartIndex(lines, next)
+		case prev < 0:
+			pos = firstBlockIndex(lines, g.vars)
+			if pos < len(lines) {
+				block = append(block, "")
+			}
+		case v.start > 0 && g.envs[v.start-1] == "":
+			for pos < len(lines) && strings.TrimSpace(lines[pos]) != "" {
+				pos++
+			}
+
+			block = append([]string{""}, block...)
+		}
+
+		lines = slices.Insert(lines, pos, block...)
+		prev = pos + len(block) - 1
+	}
+
+	return lines
+}
+
+// nextInSection returns the line index of the first following variable of the same section
+// existing in the file, -1 if not found.
+func (g *Env) nextInSection(lines []string, i int) int {
+	for j := i + 1; j < len(g.vars); j++ {
+		if slices.Contains(g.envs[g.vars[j-1].end+1:g.vars[j].start], "") {
+			return -1
+		}
+
+		if pos := g.lineIndex(lines, g.vars[j]); pos >= 0 {
+			return pos
+		}
+	}
+
+	return -1
+}
+
+// lineIndex returns the index of the generated variable line in the file, -1 if not found.
+// The variable set by its alias is found by the alias line, so it is not added with the default value
+// overriding the alias. Comment lines without a variable are matched by the content.
+func (g *Env) lineIndex(lines []string, v envVar) int {
+	if v.name != "" {
+		for _, name := range append([]string{v.name}, v.aliases...) {
+			if pos := varLineIndex(lines, name); pos >= 0 {
+				return pos
+			}
+		}
+
+		return -1
+	}
+
+	return slices.IndexFunc(lines, func(line string) bool {
+		return strings.TrimSpace(line) == g.envs[v.start]
+	})
+}
+
+func (g *Env) isRecursive(name string) bool {
+	for _, prefix := range g.recursivePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// firstBlockIndex returns the index of the comment block of the first generated variable in the file,
+// the lines count if there are no generated variables.
+func firstBlockIndex(lines []string, vars []envVar) int {
+	for i, line := range lines {
+		name := parseVarName(line)
+		if name == "" || !slices.ContainsFunc(vars, func(v envVar) bool { return v.name == name }) {
+			continue
+		}
+
+		return blockStartIndex(lines, i)
+	}
+
+	return len(lines)
+}
+
+// blockStartIndex returns the index of the comment lines block preceding the i-th line.
+func blockStartIndex(lines []string, i int) int {
+	for i > 0 && strings.HasPrefix(strings.TrimSpace(lines[i-1]), "#") {
+		i--
+	}
+
+	return i
+}
+
+func varLineIndex(lines []string, name string) int {
+	return slices.IndexFunc(lines, func(line string) bool {
+		return parseVarName(line) == name
+	})
+}
+
+// parseVarName returns the variable name of the `KEY=value` line, empty string for other lines.
+func parseVarName(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+
+	name, _, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(name)
+}
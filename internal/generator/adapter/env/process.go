@@ -1,104 +1,88 @@
 package env
 
 import (
-	"context"
 	"fmt"
-	"go/types"
-	"reflect"
+	"strings"
 
 	"github.com/kukymbr/configen/internal/generator/gentype"
 )
 
-func (g *Env) collectEnvVars(ctx context.Context, st *types.Struct, prefix string) {
-	ctx = gentype.ContextIncRecursionDepth(ctx)
-	gentype.ContextMustValidateRecursionDepth(ctx, "Env generator (collectEnvVars)")
-
-	if ctx.Err() != nil {
-		return
+func (g *Env) collectEnvVars(node *gentype.Node, prefix string) error {
+	for _, field := range node.Fields {
+		if err := g.processField(field, prefix); err != nil {
+			return err
+		}
 	}
 
-	for i := 0; i < st.NumFields(); i++ {
-		field := st.Field(i)
-		tag := st.Tag(i)
-
-		g.processField(ctx, field, tag, prefix)
-	}
+	return nil
 }
 
 //nolint:cyclop
-func (g *Env) processField(ctx context.Context, field *types.Var, tag string, prefix string) {
-	envName := gentype.ParseNameTag(tag, g.OutputOptions.Tag, "")
-	envPrefix := reflect.StructTag(tag).Get(g.OutputOptions.PrefixTag)
-	example := gentype.ParseDefaultValue(tag, gentype.ValueTagsEnv(g.OutputOptions.DefaultValueTag)...)
-	value := gentype.NewNullable[string]()
-
-	comment := g.Source.CommentsMap[field.Pos()]
-	ft := field.Type()
-
-	if field.Anonymous() {
-		g.processAnonymousField(ctx, ft, prefix)
-
-		return
-	}
+func (g *Env) processField(field *gentype.Node, prefix string) error {
+	envName := field.Key(g.OutputOptions.Tag, "")
+	envPrefix := field.TagValue(g.OutputOptions.PrefixTag)
+	example := field.Default(gentype.ValueTagsEnv(g.OutputOptions.DefaultValueTag)...)
+
+	if field.IsEmbedded {
+		// Embedded structs are expanded without a prefix.
+		if field.Kind == gentype.NodeKindStruct && field.Named != nil {
+			return g.collectEnvVars(field, prefix)
+		}
 
-	if !field.Exported() {
-		return
+		return nil
 	}
 
-	if stt, named, ok := gentype.GetUnderlyingStruct(ft); ok {
+	if field.IsStructLike() {
 		if len(g.envs) > 0 && g.envs[len(g.envs)-1] != "" {
 			// Separate substructs with space.
 			g.envs = append(g.envs, "")
 		}
 
-		if named == nil {
-			g.collectEnvVars(ctx, stt, prefix+envPrefix)
-
-			return
+		if field.Kind == gentype.NodeKindOpaque {
+			return nil
 		}
 
-		if gentype.IsTextUnmarshaler(stt) {
-			value.Set(gentype.DefaultValueForType(ft, example))
+		if field.IsRecursive {
+			g.recursivePrefixes = append(g.recursivePrefixes, prefix+envPrefix)
+			g.envs = append(g.envs, fmt.Sprintf("# %s%s*: %s", prefix, envPrefix, gentype.GetRecursionComment(field.Named)))
+			g.vars = append(g.vars, envVar{start: len(g.envs) - 1, end: len(g.envs) - 1})
 
-			return
+			return nil
 		}
 
-		if !g.isTargetPackage(named) {
-			return
+		if err := g.collectEnvVars(field, prefix+envPrefix); err != nil {
+			return err
 		}
 
-		g.collectEnvVars(ctx, stt, prefix+envPrefix)
+		if field.Named == nil {
+			return nil
+		}
 	}
 
 	if envName == "" {
-		return
-	}
-
-	if !value.IsSet() {
-		value.Set(gentype.DefaultValueForType(ft, example))
+		return nil
 	}
 
-	if comment != "" {
-		g.envs = append(g.envs, fmt.Sprintf("# %s", comment))
+	value, err := field.Value(example)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", field.PathString(), err)
 	}
 
-	g.envs = append(g.envs, fmt.Sprintf("%s%s=%s", prefix, envName, value.Value()))
-}
+	start := len(g.envs)
 
-// processAnonymousField expands anonymous embedded struct fields in env values.
-func (g *Env) processAnonymousField(ctx context.Context, ft types.Type, prefix string) {
-	stt, named, ok := gentype.GetUnderlyingStruct(ft)
-	if !ok {
-		return
+	if comment := gentype.JoinComments(field.Comment, field.EnumComment(), field.DeprecatedComment()); comment != "" {
+		for _, line := range strings.Split(comment, "\n") {
+			g.envs = append(g.envs, fmt.Sprintf("# %s", line))
+		}
 	}
 
-	if named == nil {
-		return
+	g.envs = append(g.envs, fmt.Sprintf("%s%s=%s", prefix, envName, value))
+	v := envVar{name: prefix + envName, start: start, end: len(g.envs) - 1}
+	for _, alias := range field.EnvAliases() {
+		v.aliases = append(v.aliases, prefix+alias)
 	}
 
-	g.collectEnvVars(ctx, stt, prefix)
-}
+	g.vars = append(g.vars, v)
 
-func (g *Env) isTargetPackage(name any) bool {
-	return gentype.ParsePackageName(name) == gentype.ParsePackageName(g.Source.Package)
+	return nil
 }
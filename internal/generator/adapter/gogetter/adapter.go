@@ -3,7 +3,11 @@ package gogetter
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"go/format"
+	"go/types"
+	"maps"
+	"slices"
 
 	"github.com/kukymbr/configen/internal/generator/gentype"
 	"github.com/kukymbr/configen/internal/logger"
@@ -14,29 +18,139 @@ type GoGetter struct {
 	gentype.GenericAdapter
 
 	collectedStructs map[string]*StructInfo
-	collectedImports map[string]struct{}
+	// collectedImports is a map of the import paths to the package qualifiers.
+	collectedImports map[string]string
 }
 
-func New(sourceStruct gentype.Source, outputOptions gentype.OutputOptions) *GoGetter {
+// Names of the OutputOptions.Params values.
+const (
+	ParamInterfaces = "interfaces"
+	ParamMocks      = "mocks"
+	ParamStore      = "store"
+	ParamDiff       = "diff"
+)
+
+func New(model *gentype.Model, outputOptions gentype.OutputOptions) *GoGetter {
 	return &GoGetter{
-		GenericAdapter: gentype.GenericAdapter{
-			Source:        sourceStruct,
-			OutputOptions: outputOptions,
-		},
+		GenericAdapter: gentype.NewGenericAdapter(model, outputOptions),
 
 		collectedStructs: make(map[string]*StructInfo),
-		collectedImports: make(map[string]struct{}),
+		collectedImports: make(map[string]string),
+	}
+}
+
+// Options returns the options declared by the Go getter adapter.
+func Options() []gentype.AdapterOption {
+	return []gentype.AdapterOption{
+		{
+			Name:  "struct",
+			Usage: "Target struct name (default is exported variant of incoming struct name)",
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.TargetStructName = value
+			},
+		},
+		{
+			Name:  "pkg",
+			Usage: "Target package name",
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.TargetPackageName = value
+			},
+		},
+		{
+			Name:  "template",
+			Usage: "Path to the template overriding the built-in one or its define blocks",
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.TemplatePath = value
+			},
+		},
+		{
+			Name:    ParamInterfaces,
+			Usage:   "Generate the <Struct>Reader interface for each struct",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    ParamMocks,
+			Usage:   "Generate the <Struct>Mock implementation with settable values for each struct, enables interfaces",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    ParamStore,
+			Usage:   "Generate the <Struct>Store holding the root struct snapshot for the hot reload",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    ParamDiff,
+			Usage:   "Generate the Equal and Diff methods for each struct",
+			Default: "false",
+			Bool:    true,
+		},
 	}
 }
 
 func (g *GoGetter) Generate(ctx context.Context) (gentype.OutputFiles, error) {
 	if g.OutputOptions.TargetPackageName == "" {
-		g.OutputOptions.TargetPackageName = packageNameFromID(g.Source.Package.ID)
+		g.OutputOptions.TargetPackageName = g.Source.Package.Types.Name()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := g.processStruct(g.Model.Root, g.Source.Named, g.OutputOptions.TargetStructName, false); err != nil {
+		return nil, err
+	}
+
+	withInterfaces, err := g.OutputOptions.BoolParam(ParamInterfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	withMocks, err := g.OutputOptions.BoolParam(ParamMocks)
+	if err != nil {
+		return nil, err
+	}
+
+	withStore, err := g.OutputOptions.BoolParam(ParamStore)
+	if err != nil {
+		return nil, err
+	}
+
+	var store *StoreInfo
+	if withStore {
+		store = &StoreInfo{
+			Name:             g.OutputOptions.TargetStructName + "Store",
+			TargetStructName: g.OutputOptions.TargetStructName,
+			SourceStructName: g.Source.RootStructName,
+			SyncPkg:          g.registerImport(types.NewPackage("sync", "sync")),
+			AtomicPkg:        g.registerImport(types.NewPackage("sync/atomic", "atomic")),
+		}
 	}
 
-	g.processStruct(ctx, g.Source.Named, g.Source.Struct, g.OutputOptions.TargetStructName, false)
+	withDiff, err := g.OutputOptions.BoolParam(ParamDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff *DiffInfo
+	if withDiff {
+		if err := g.checkDiffMethods(); err != nil {
+			return nil, err
+		}
+
+		diff = &DiffInfo{
+			ReflectPkg: g.registerImport(types.NewPackage("reflect", "reflect")),
+		}
+	}
 
 	tplData := tplData{
+		Store:            store,
+		Diff:             diff,
+		Aliases:          g.collectAliases(),
+		WithInterfaces:   withInterfaces || withMocks,
+		WithMocks:        withMocks,
 		Structs:          g.collectedStructs,
 		Imports:          g.getImports(),
 		PackageName:      g.OutputOptions.TargetPackageName,
@@ -46,7 +160,7 @@ func (g *GoGetter) Generate(ctx context.Context) (gentype.OutputFiles, error) {
 	}
 
 	var buf bytes.Buffer
-	if err := executeTemplate(&buf, tplData); err != nil {
+	if err := executeTemplate(&buf, tplData, g.OutputOptions.TemplatePath); err != nil {
 		return nil, err
 	}
 
@@ -61,3 +175,22 @@ func (g *GoGetter) Generate(ctx context.Context) (gentype.OutputFiles, error) {
 
 	return gentype.OutputFiles{content}, nil
 }
+
+// diffMethods are the names of the methods generated with the ParamDiff.
+var diffMethods = []string{"Equal", "Diff"}
+
+// checkDiffMethods checks that the getters of the fields don't conflict with the ParamDiff methods.
+func (g *GoGetter) checkDiffMethods() error {
+	for _, name := range slices.Sorted(maps.Keys(g.collectedStructs)) {
+		for _, field := range g.collectedStructs[name].Fields {
+			if slices.Contains(diffMethods, field.ExportName) {
+				return fmt.Errorf(
+					"field %s: getter conflicts with the %s method of the %s param, rename the field or disable the param",
+					field.PathString(), field.ExportName, ParamDiff,
+				)
+			}
+		}
+	}
+
+	return nil
+}
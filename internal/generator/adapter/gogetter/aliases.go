@@ -0,0 +1,114 @@
+package gogetter
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// AliasesInfo describes the generated resolvers of the deprecated keys aliases.
+type AliasesInfo struct {
+	// RootStructName is a name of the generated root struct, used as the resolvers names suffix.
+	RootStructName string
+
+	// OsPkg is a qualifier of the os package.
+	OsPkg string
+
+	// Env are the aliases of the dotenv variables.
+	Env []AliasInfo
+
+	// YAML are the aliases of the YAML keys.
+	YAML []AliasInfo
+}
+
+// AliasInfo is a config key with its old names.
+type AliasInfo struct {
+	// Parent is a YAML keys path of the parent mapping, empty for the dotenv variables.
+	Parent []string
+
+	// Name is a dotenv variable name or a YAML key.
+	Name string
+
+	// Aliases are the old names of the key, with the prefix for the dotenv variables.
+	Aliases []string
+}
+
+// KeyPrefix returns the YAML keys path of the parent mapping with the trailing dot, e.g. `api.`.
+func (a AliasInfo) KeyPrefix() string {
+	if len(a.Parent) == 0 {
+		return ""
+	}
+
+	return strings.Join(a.Parent, ".") + "."
+}
+
+// collectAliases collects the aliases of the YAML keys and dotenv variables,
+// returns nil if there are no aliases.
+func (g *GoGetter) collectAliases() *AliasesInfo {
+	c := &aliasesCollector{
+		yamlTag:   valueOrDefault(g.OutputOptions.Tag, gentype.TagYAML),
+		envTag:    valueOrDefault(g.OutputOptions.Params[gentype.ParamEnvTag], gentype.TagEnv),
+		prefixTag: valueOrDefault(g.OutputOptions.PrefixTag, gentype.TagEnvPrefix),
+	}
+
+	c.collect(g.Model.Root, nil, true, "")
+
+	if len(c.info.Env) == 0 && len(c.info.YAML) == 0 {
+		return nil
+	}
+
+	c.info.RootStructName = g.OutputOptions.TargetStructName
+	c.info.OsPkg = g.registerImport(types.NewPackage("os", "os"))
+
+	return &c.info
+}
+
+type aliasesCollector struct {
+	yamlTag   string
+	envTag    string
+	prefixTag string
+
+	info AliasesInfo
+}
+
+// collect walks the struct fields the same way as the YAML and dotenv adapters.
+func (c *aliasesCollector) collect(node *gentype.Node, parent []string, inYAML bool, envPrefix string) {
+	for _, field := range node.Fields {
+		yamlKey := field.Key(c.yamlTag, field.Name)
+
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct && field.Named != nil {
+				c.collect(field, parent, inYAML && yamlKey != "", envPrefix)
+			}
+
+			continue
+		}
+
+		if aliases := field.YAMLAliases(); len(aliases) > 0 && inYAML && yamlKey != "" {
+			c.info.YAML = append(c.info.YAML, AliasInfo{Parent: parent, Name: yamlKey, Aliases: aliases})
+		}
+
+		if envName, aliases := field.Key(c.envTag, ""), field.EnvAliases(); len(aliases) > 0 && envName != "" {
+			envAliases := make([]string, 0, len(aliases))
+			for _, alias := range aliases {
+				envAliases = append(envAliases, envPrefix+alias)
+			}
+
+			c.info.Env = append(c.info.Env, AliasInfo{Name: envPrefix + envName, Aliases: envAliases})
+		}
+
+		if field.Kind == gentype.NodeKindStruct && !field.IsRecursive {
+			c.collect(field, append(parent[:len(parent):len(parent)], yamlKey), inYAML && yamlKey != "",
+				envPrefix+field.TagValue(c.prefixTag))
+		}
+	}
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
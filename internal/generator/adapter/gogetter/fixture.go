@@ -0,0 +1,178 @@
+package gogetter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"go/types"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/kukymbr/configen/internal/logger"
+	"github.com/kukymbr/configen/internal/version"
+)
+
+// Fixture generates the test fixture builder of the Go getter root struct.
+type Fixture struct {
+	valueExprs
+
+	defaults []fixtureDefault
+	setters  []fixtureSetter
+}
+
+type fixtureData struct {
+	PackageName string
+	Version     string
+	Imports     []string
+
+	// IsTestFile is true if the fixture is written to the _test.go file,
+	// which has no package doc comment not to override the package one.
+	IsTestFile bool
+
+	// BuilderName is a name of the builder struct, e.g. `ConfigBuilder`.
+	BuilderName string
+	// TargetStructName is a name of the generated Go getter root struct.
+	TargetStructName string
+	// SourceStructName is a name of the source root struct.
+	SourceStructName string
+	// HelpersPrefix is a prefix of the helper functions names, unique for the root struct.
+	HelpersPrefix string
+	// UsedHelpers are the names of the helper functions used in the defaults, without prefix.
+	UsedHelpers map[string]bool
+
+	Defaults []fixtureDefault
+	Setters  []fixtureSetter
+}
+
+// fixtureDefault is a default value assignment.
+type fixtureDefault struct {
+	// Path is a source field path, e.g. `API.Port`.
+	Path string
+	// Value is a Go expression of the value, empty if value is not supported.
+	Value string
+	// Raw is a raw default value from the tag.
+	Raw string
+}
+
+// fixtureSetter is a fluent setter of the field.
+type fixtureSetter struct {
+	Name     string
+	Path     string
+	TypeName string
+}
+
+func NewFixture(model *gentype.Model, outputOptions gentype.OutputOptions) *Fixture {
+	return &Fixture{
+		valueExprs: newValueExprs(New(model, outputOptions)),
+	}
+}
+
+func (g *Fixture) Generate(ctx context.Context) (gentype.OutputFiles, error) {
+	if g.OutputOptions.TargetPackageName == "" {
+		g.OutputOptions.TargetPackageName = g.Source.Package.Types.Name()
+	}
+
+	if g.OutputOptions.TargetStructName == "" {
+		g.OutputOptions.TargetStructName = gentype.ToPublicName(g.Source.RootStructName)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	g.helpersPrefix = gentype.ToLowerCamel(g.OutputOptions.TargetStructName) + "Fixture"
+
+	if err := g.collectFields(g.Model.Root); err != nil {
+		return nil, err
+	}
+
+	data := fixtureData{
+		PackageName:      g.OutputOptions.TargetPackageName,
+		Version:          version.GetVersion(),
+		IsTestFile:       strings.HasSuffix(g.OutputOptions.Path, "_test.go"),
+		BuilderName:      g.OutputOptions.TargetStructName + "Builder",
+		TargetStructName: g.OutputOptions.TargetStructName,
+		SourceStructName: g.formatTypeName(g.Source.Named),
+		HelpersPrefix:    g.helpersPrefix,
+		UsedHelpers:      g.usedHelpers,
+		Defaults:         g.defaults,
+		Setters:          g.setters,
+	}
+
+	if g.usedHelpers["Text"] {
+		g.registerImport(types.NewPackage("encoding", "encoding"))
+	}
+
+	data.Imports = g.getImports()
+
+	var buf bytes.Buffer
+	if err := executeHelpersTemplate(&buf, fixtureTemplateName, data); err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+
+	formatted, err := format.Source(content)
+	if err == nil {
+		content = formatted
+	} else {
+		logger.Warningf("Failed to format generated code: %s", err.Error())
+	}
+
+	return gentype.OutputFiles{content}, nil
+}
+
+// collectFields collects the defaults and setters of the fields,
+// nested structs are expanded unless they are referenced by a pointer.
+func (g *Fixture) collectFields(node *gentype.Node) error {
+	for _, field := range node.Fields {
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct && !field.IsPointer {
+				if err := g.collectFields(field); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if field.Kind == gentype.NodeKindStruct && !field.IsPointer && !field.IsRecursive {
+			if err := g.collectFields(field); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		g.setters = append(g.setters, fixtureSetter{
+			Name:     "With" + strings.Join(field.Path, ""),
+			Path:     field.PathString(),
+			TypeName: g.formatTypeName(field.Type),
+		})
+
+		raw := field.Default(gentype.ValueTagsYAML(g.OutputOptions.DefaultValueTag)...)
+		if raw == "" {
+			continue
+		}
+
+		if field.Scalar != nil && field.Scalar.Parse != nil {
+			if err := field.Scalar.Parse(raw); err != nil {
+				return fmt.Errorf("field %s: invalid value %q: %w", field.PathString(), raw, err)
+			}
+		}
+
+		value, err := g.valueExpr(field.Type, raw)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.PathString(), err)
+		}
+
+		g.defaults = append(g.defaults, fixtureDefault{
+			Path:  field.PathString(),
+			Value: value,
+			Raw:   raw,
+		})
+	}
+
+	return nil
+}
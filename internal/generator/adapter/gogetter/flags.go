@@ -0,0 +1,448 @@
+package gogetter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/kukymbr/configen/internal/logger"
+	"github.com/kukymbr/configen/internal/version"
+)
+
+// Names of the flags OutputOptions.Params values.
+const (
+	ParamFlagsLib = "lib"
+)
+
+// Flag libraries supported by the flags adapter.
+const (
+	FlagsLibPflag = "pflag"
+	FlagsLibStd   = "flag"
+)
+
+// Flags generates the command line flags bindings of the source struct fields.
+type Flags struct {
+	valueExprs
+
+	lib   string
+	flags []flagInfo
+}
+
+type flagsData struct {
+	PackageName string
+	Version     string
+	Imports     []string
+
+	// RegisterFuncName is a name of the flags registration function, e.g. `RegisterConfigFlags`.
+	RegisterFuncName string
+	// MergeFuncName is a name of the flags merge function, e.g. `MergeConfigFlags`.
+	MergeFuncName string
+	// SourceStructName is a name of the source root struct.
+	SourceStructName string
+	// FlagsPkg is a qualifier of the flags package.
+	FlagsPkg string
+	// HelpersPrefix is a prefix of the helper functions names, unique for the root struct.
+	HelpersPrefix string
+	// UsedHelpers are the names of the helper functions used in the defaults, without prefix.
+	UsedHelpers map[string]bool
+
+	Flags []flagInfo
+}
+
+// flagInfo is a flag bound to the source struct field.
+type flagInfo struct {
+	// Name is a flag name, e.g. `api.port`.
+	Name string
+	// Path is a source field path, e.g. `API.Port`.
+	Path string
+	// TypeName is a field type name.
+	TypeName string
+	// Usage is a flag usage text.
+	Usage string
+	// Method is a name of the FlagSet method, e.g. `IntVar`, empty if type is not supported.
+	Method string
+	// Ptr is a pointer expression of the bound field, e.g. `(*string)(&dto.Logger.Format)`.
+	Ptr string
+	// Default is a Go expression of the default value, or the flag.Value for the `Var` method.
+	Default string
+}
+
+func NewFlags(model *gentype.Model, outputOptions gentype.OutputOptions) *Flags {
+	return &Flags{
+		valueExprs: newValueExprs(New(model, outputOptions)),
+	}
+}
+
+// FlagsOptions returns the options declared by the flags adapter.
+func FlagsOptions() []gentype.AdapterOption {
+	return []gentype.AdapterOption{
+		{
+			Name:  "pkg",
+			Usage: "Target package name of the flags bindings",
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.TargetPackageName = value
+			},
+		},
+		{
+			Name:    "tag",
+			Usage:   "Tag name for the flag names, overridden by the flag tag",
+			Default: gentype.TagYAML,
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.Tag = value
+			},
+		},
+		{
+			Name:    ParamFlagsLib,
+			Usage:   "Flags library: pflag or flag",
+			Default: FlagsLibPflag,
+		},
+	}
+}
+
+func (g *Flags) Generate(ctx context.Context) (gentype.OutputFiles, error) {
+	if g.OutputOptions.TargetPackageName == "" {
+		g.OutputOptions.TargetPackageName = g.Source.Package.Types.Name()
+	}
+
+	if g.OutputOptions.TargetStructName == "" {
+		g.OutputOptions.TargetStructName = gentype.ToPublicName(g.Source.RootStructName)
+	}
+
+	if g.OutputOptions.Tag == "" {
+		g.OutputOptions.Tag = gentype.TagYAML
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	g.lib = g.OutputOptions.Params[ParamFlagsLib]
+	if g.lib == "" {
+		g.lib = FlagsLibPflag
+	}
+
+	var flagsPkg *types.Package
+
+	switch g.lib {
+	case FlagsLibPflag:
+		flagsPkg = types.NewPackage("github.com/spf13/pflag", "pflag")
+	case FlagsLibStd:
+		flagsPkg = types.NewPackage("flag", "flag")
+	default:
+		return nil, fmt.Errorf("invalid %s option value %q: expected %s or %s",
+			ParamFlagsLib, g.lib, FlagsLibPflag, FlagsLibStd)
+	}
+
+	g.helpersPrefix = gentype.ToLowerCamel(g.OutputOptions.TargetStructName) + "Flags"
+
+	if err := g.collectFlags(g.Model.Root, ""); err != nil {
+		return nil, err
+	}
+
+	data := flagsData{
+		PackageName:      g.OutputOptions.TargetPackageName,
+		Version:          version.GetVersion(),
+		RegisterFuncName: "Register" + g.OutputOptions.TargetStructName + "Flags",
+		MergeFuncName:    "Merge" + g.OutputOptions.TargetStructName + "Flags",
+		SourceStructName: g.formatTypeName(g.Source.Named),
+		FlagsPkg:         g.registerImport(flagsPkg),
+		HelpersPrefix:    g.helpersPrefix,
+		UsedHelpers:      g.usedHelpers,
+		Flags:            g.flags,
+	}
+
+	if g.usedHelpers["TextValue"] || g.usedHelpers["TextPtrVar"] || g.usedHelpers["TextSliceVar"] {
+		g.registerImport(types.NewPackage("encoding", "encoding"))
+	}
+
+	if g.usedHelpers["TextSliceVar"] {
+		g.registerImport(types.NewPackage("strings", "strings"))
+	}
+
+	if g.usedHelpers["URLVar"] {
+		g.registerImport(types.NewPackage("net/url", "url"))
+	}
+
+	data.Imports = g.getImports()
+
+	var buf bytes.Buffer
+	if err := executeHelpersTemplate(&buf, flagsTemplateName, data); err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+
+	formatted, err := format.Source(content)
+	if err == nil {
+		content = formatted
+	} else {
+		logger.Warningf("Failed to format generated code: %s", err.Error())
+	}
+
+	return gentype.OutputFiles{content}, nil
+}
+
+// collectFlags collects the flags of the struct fields,
+// nested structs are expanded unless they are referenced by a pointer.
+func (g *Flags) collectFlags(node *gentype.Node, prefix string) error {
+	for _, field := range node.Fields {
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct && !field.IsPointer {
+				if err := g.collectFlags(field, prefix); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		name := g.flagName(field, prefix)
+		if name == "" {
+			continue
+		}
+
+		if field.Kind == gentype.NodeKindStruct && !field.IsPointer && !field.IsRecursive {
+			if err := g.collectFlags(field, name+"."); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		flag := flagInfo{
+			Name:     name,
+			Path:     field.PathString(),
+			TypeName: types.TypeString(field.Type, g.nameQualifier),
+			Usage:    flagUsage(field.Comment, field.EnumComment(), field.DeprecatedComment()),
+		}
+
+		if err := g.bindFlag(field, &flag); err != nil {
+			return fmt.Errorf("field %s: %w", field.PathString(), err)
+		}
+
+		g.flags = append(g.flags, flag)
+	}
+
+	return nil
+}
+
+// nameQualifier qualifies the types by the package name without registering the import,
+// to mention the types in the comments.
+func (g *Flags) nameQualifier(pkg *types.Package) string {
+	if g.isSourcePackage(pkg) {
+		return ""
+	}
+
+	return pkg.Name()
+}
+
+// flagName returns the flag name of the field, empty if field is skipped.
+// Name is the key path from the tag, overridden by the `flag` tag.
+func (g *Flags) flagName(field *gentype.Node, prefix string) string {
+	if override := field.TagValue(gentype.TagFlag); override != "" {
+		if override == "-" {
+			return ""
+		}
+
+		return override
+	}
+
+	key := field.Key(g.OutputOptions.Tag, field.Name)
+	if key == "" {
+		return ""
+	}
+
+	return prefix + key
+}
+
+// bindFlag fills the FlagSet method, bound pointer and default value of the flag,
+// method is left empty if field type is not supported.
+//
+//nolint:cyclop
+func (g *Flags) bindFlag(field *gentype.Node, flag *flagInfo) error {
+	raw := field.Default(gentype.ValueTagsYAML(g.OutputOptions.DefaultValueTag)...)
+	ptr := "&dto." + flag.Path
+
+	if field.Scalar != nil && field.Scalar.Parse != nil && raw != "" {
+		if err := field.Scalar.Parse(raw); err != nil {
+			return fmt.Errorf("invalid value %q: %w", raw, err)
+		}
+	}
+
+	t := field.Type
+
+	if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Duration" {
+		return g.bindBasic(flag, "DurationVar", ptr, t, raw)
+	}
+
+	if pointer, isPointer := types.Unalias(t).(*types.Pointer); isPointer {
+		g.bindPointer(flag, pointer.Elem(), ptr, raw)
+
+		return nil
+	}
+
+	if gentype.IsTextUnmarshaler(t) {
+		g.helper("TextValue")
+
+		flag.Method = "Var"
+		flag.Default = g.helpersPrefix + "TextVar(" + ptr + ", " + strconv.Quote(raw) + ")"
+
+		return nil
+	}
+
+	if slice, ok := types.Unalias(t).(*types.Slice); ok {
+		if _, isBasic := slice.Elem().Underlying().(*types.Basic); !isBasic && gentype.IsTextUnmarshaler(slice.Elem()) {
+			flag.Method = "Var"
+			flag.Default = g.helper("TextSliceVar") + "(" + ptr + ", " + strconv.Quote(raw) + ")"
+			g.helper("FuncValue")
+
+			return nil
+		}
+
+		elem, ok := types.Unalias(slice.Elem()).(*types.Basic)
+		if !ok || g.lib != FlagsLibPflag {
+			return nil
+		}
+
+		switch elem.Kind() {
+		case types.String:
+			return g.bindBasic(flag, "StringSliceVar", ptr, t, raw)
+		case types.Int:
+			return g.bindBasic(flag, "IntSliceVar", ptr, t, raw)
+		}
+
+		return nil
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return nil
+	}
+
+	method := g.basicMethod(basic)
+	if method == "" {
+		return nil
+	}
+
+	// Named basic types are bound by the pointer conversion to the underlying type.
+	if _, isBasic := types.Unalias(t).(*types.Basic); !isBasic {
+		ptr = "(*" + basic.Name() + ")(" + ptr + ")"
+	}
+
+	return g.bindBasic(flag, method, ptr, basic, raw)
+}
+
+// bindPointer binds the pointers to the URL and the encoding.TextUnmarshaler types,
+// the value is allocated when the flag is set, method is left empty if type is not supported.
+func (g *Flags) bindPointer(flag *flagInfo, elem types.Type, ptr string, raw string) {
+	var constructor string
+
+	switch named, _ := types.Unalias(elem).(*types.Named); {
+	case named != nil && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "net/url" && named.Obj().Name() == "URL":
+		constructor = g.helper("URLVar")
+	case gentype.IsTextUnmarshaler(elem):
+		constructor = g.helper("TextPtrVar")
+	default:
+		return
+	}
+
+	g.helper("FuncValue")
+
+	flag.Method = "Var"
+	flag.Default = constructor + "(" + ptr + ", " + strconv.Quote(raw) + ")"
+}
+
+func (g *Flags) bindBasic(flag *flagInfo, method string, ptr string, t types.Type, raw string) error {
+	flag.Method = method
+	flag.Ptr = ptr
+
+	if raw == "" {
+		flag.Default = zeroValueExpr(t)
+
+		return nil
+	}
+
+	value, err := g.valueExpr(t, raw)
+	if err != nil {
+		return err
+	}
+
+	flag.Default = value
+
+	return nil
+}
+
+// basicMethod returns the FlagSet method name of the basic type, empty if type is not supported.
+func (g *Flags) basicMethod(t *types.Basic) string {
+	switch t.Kind() {
+	case types.Bool:
+		return "BoolVar"
+	case types.String:
+		return "StringVar"
+	case types.Int:
+		return "IntVar"
+	case types.Int64:
+		return "Int64Var"
+	case types.Uint:
+		return "UintVar"
+	case types.Uint64:
+		return "Uint64Var"
+	case types.Float64:
+		return "Float64Var"
+	}
+
+	if g.lib != FlagsLibPflag {
+		return ""
+	}
+
+	switch t.Kind() {
+	case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32, types.Float32:
+		return gentype.ToCamel(t.Name()) + "Var"
+	}
+
+	return ""
+}
+
+// flagUsage joins the comments into a single line usage text, separating them with the dot.
+func flagUsage(comments ...string) string {
+	var usage string
+
+	for _, comment := range comments {
+		comment = strings.Join(strings.Fields(comment), " ")
+
+		switch {
+		case comment == "":
+			continue
+		case usage == "":
+			usage = comment
+		case strings.HasSuffix(usage, ".") || strings.HasSuffix(usage, ":"):
+			usage += " " + comment
+		default:
+			usage += ". " + comment
+		}
+	}
+
+	return usage
+}
+
+func zeroValueExpr(t types.Type) string {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "nil"
+	}
+
+	switch info := basic.Info(); {
+	case info&types.IsBoolean != 0:
+		return "false"
+	case info&types.IsString != 0:
+		return `""`
+	}
+
+	return "0"
+}
@@ -0,0 +1,193 @@
+{{- define "helpers" -}}
+{{- if .UsedHelpers.Must }}
+
+func {{ .HelpersPrefix }}Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+{{- end }}
+
+{{- if .UsedHelpers.Ptr }}
+
+func {{ .HelpersPrefix }}Ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
+
+{{- if .UsedHelpers.Text }}
+
+func {{ .HelpersPrefix }}Text[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](value string) T {
+	var v T
+
+	if err := P(&v).UnmarshalText([]byte(value)); err != nil {
+		panic(err)
+	}
+
+	return v
+}
+{{- end }}
+
+{{- if .UsedHelpers.TextValue }}
+
+// {{ .HelpersPrefix }}TextValue is a flag value of the encoding.TextUnmarshaler type.
+type {{ .HelpersPrefix }}TextValue[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}] struct {
+	ptr *T
+	raw string
+}
+
+func {{ .HelpersPrefix }}TextVar[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](ptr *T, value string) *{{ .HelpersPrefix }}TextValue[T, P] {
+	v := &{{ .HelpersPrefix }}TextValue[T, P]{ptr: ptr}
+
+	if value != "" {
+		if err := v.Set(value); err != nil {
+			panic(err)
+		}
+	}
+
+	return v
+}
+
+func (v *{{ .HelpersPrefix }}TextValue[T, P]) String() string {
+	return v.raw
+}
+
+func (v *{{ .HelpersPrefix }}TextValue[T, P]) Set(value string) error {
+	if err := P(v.ptr).UnmarshalText([]byte(value)); err != nil {
+		return err
+	}
+
+	v.raw = value
+
+	return nil
+}
+
+func (v *{{ .HelpersPrefix }}TextValue[T, P]) Type() string {
+	return "value"
+}
+{{- end }}
+
+{{- if .UsedHelpers.FuncValue }}
+
+// {{ .HelpersPrefix }}FuncValue is a flag value parsed by the set function.
+type {{ .HelpersPrefix }}FuncValue struct {
+	raw string
+	set func(value string) error
+}
+
+func {{ .HelpersPrefix }}FuncVar(value string, set func(value string) error) *{{ .HelpersPrefix }}FuncValue {
+	v := &{{ .HelpersPrefix }}FuncValue{set: set}
+
+	if value != "" {
+		if err := v.Set(value); err != nil {
+			panic(err)
+		}
+	}
+
+	return v
+}
+
+func (v *{{ .HelpersPrefix }}FuncValue) String() string {
+	return v.raw
+}
+
+func (v *{{ .HelpersPrefix }}FuncValue) Set(value string) error {
+	if err := v.set(value); err != nil {
+		return err
+	}
+
+	v.raw = value
+
+	return nil
+}
+
+func (v *{{ .HelpersPrefix }}FuncValue) Type() string {
+	return "value"
+}
+{{- end }}
+
+{{- if .UsedHelpers.URLVar }}
+
+// {{ .HelpersPrefix }}URLVar returns the flag value parsing the URL.
+func {{ .HelpersPrefix }}URLVar(ptr **url.URL, value string) *{{ .HelpersPrefix }}FuncValue {
+	return {{ .HelpersPrefix }}FuncVar(value, func(value string) error {
+		u, err := url.Parse(value)
+		if err != nil {
+			return err
+		}
+
+		*ptr = u
+
+		return nil
+	})
+}
+{{- end }}
+
+{{- if .UsedHelpers.TextPtrVar }}
+
+// {{ .HelpersPrefix }}TextPtrVar returns the flag value allocating the encoding.TextUnmarshaler value.
+func {{ .HelpersPrefix }}TextPtrVar[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](ptr **T, value string) *{{ .HelpersPrefix }}FuncValue {
+	return {{ .HelpersPrefix }}FuncVar(value, func(value string) error {
+		v := new(T)
+		if err := P(v).UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+
+		*ptr = v
+
+		return nil
+	})
+}
+{{- end }}
+
+{{- if .UsedHelpers.TextSliceVar }}
+
+// {{ .HelpersPrefix }}TextSliceVar returns the flag value of the comma-separated encoding.TextUnmarshaler values.
+// The first flag value replaces the default, the next ones are appended.
+func {{ .HelpersPrefix }}TextSliceVar[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](ptr *[]T, value string) *{{ .HelpersPrefix }}FuncValue {
+	replace := true
+
+	v := {{ .HelpersPrefix }}FuncVar(value, func(value string) error {
+		items := make([]T, 0)
+
+		for _, item := range strings.Split(value, ",") {
+			var v T
+			if err := P(&v).UnmarshalText([]byte(strings.TrimSpace(item))); err != nil {
+				return err
+			}
+
+			items = append(items, v)
+		}
+
+		if replace {
+			*ptr, replace = items, false
+		} else {
+			*ptr = append(*ptr, items...)
+		}
+
+		return nil
+	})
+
+	replace = true
+
+	return v
+}
+{{- end }}
+{{- end }}
@@ -1,180 +1,179 @@
 package gogetter
 
 import (
-	"context"
 	"fmt"
 	"go/token"
 	"go/types"
+	"path"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/kukymbr/configen/internal/generator/gentype"
 )
 
 func (g *GoGetter) processStruct(
-	ctx context.Context,
+	node *gentype.Node,
 	named *types.Named,
-	st *types.Struct,
 	targetStructName string,
 	isAnon bool,
-) *StructInfo {
-	ctx = gentype.ContextIncRecursionDepth(ctx)
-	gentype.ContextMustValidateRecursionDepth(ctx, "Go generator (processStruct)")
-
-	if ctx.Err() != nil {
-		return nil
-	}
-
-	syntaxMap := g.Source.SyntaxMap
-
+) (*StructInfo, error) {
 	if targetStructName == "" {
-		targetStructName = gentype.ToPublicName(named.Obj().Name())
+		targetStructName = g.publicStructName(named)
 	}
 
+	sourceStructName := g.formatTypeName(named)
+
 	if info, exists := g.collectedStructs[targetStructName]; exists {
-		return info
+		if info.SourceStructName != sourceStructName {
+			return nil, fmt.Errorf(
+				"generated struct name %s is the same for %s and %s",
+				targetStructName, info.SourceStructName, sourceStructName,
+			)
+		}
+
+		return info, nil
 	}
 
 	info := &StructInfo{
 		Name:             targetStructName,
-		SourceStructName: named.Obj().Name(),
+		SourceStructName: sourceStructName,
 		IsAnonymous:      isAnon,
 	}
 
-	if syn, ok := syntaxMap[named.Obj().Name()]; ok {
+	if syn, ok := g.Source.SyntaxMap[named.Obj().Name()]; ok {
 		info.Doc = docComment(syn)
 	}
 
-	for i := 0; i < st.NumFields(); i++ {
-		field := st.Field(i)
-		ft := field.Type()
+	// Registered before the fields processing to stop on the self-referencing structs.
+	g.collectedStructs[targetStructName] = info
 
-		if fieldInfo := g.processField(ctx, field, ft, named.Obj().Name(), targetStructName, i); fieldInfo != nil {
-			info.Fields = append(info.Fields, fieldInfo...)
+	for _, field := range node.Fields {
+		fieldInfo, err := g.processField(field, targetStructName)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	g.collectedStructs[targetStructName] = info
+		info.Fields = append(info.Fields, fieldInfo...)
+	}
 
-	return info
+	return info, nil
 }
 
-//nolint:cyclop
-func (g *GoGetter) processField(
-	ctx context.Context,
-	field *types.Var,
-	ft types.Type,
-	sourceStructName string,
-	targetStructName string,
-	fieldIndex int,
-) []FieldInfo {
+func (g *GoGetter) processField(field *gentype.Node, targetStructName string) ([]FieldInfo, error) {
 	// Handle anonymous embedded structs by flattening their fields
-	if field.Anonymous() {
-		return g.processAnonymousField(ctx, field, targetStructName)
+	if field.IsEmbedded {
+		return g.processAnonymousField(field, targetStructName)
 	}
 
-	if !field.Exported() {
-		return nil
-	}
+	var (
+		structInfo *StructInfo
+		err        error
+	)
 
-	var structInfo *StructInfo
+	typeName := g.formatTypeName(field.Type)
+	isPointer := false
 
-	typeName := g.formatTypeName(ft)
-	processed := false
+	if field.Kind == gentype.NodeKindStruct {
+		switch {
+		case field.Named != nil && g.isTargetPackage(field.Named.Obj().Pkg()):
+			typeName = g.publicStructName(field.Named)
 
-	if nt, ok := ft.(*types.Named); ok {
-		if _, ok := nt.Underlying().(*types.Struct); ok && g.isTargetPackage(nt.Obj().Pkg()) {
-			typeName = gentype.ToPublicName(nt.Obj().Name())
-			structInfo = g.processStruct(ctx, nt, nt.Underlying().(*types.Struct), typeName, false)
-			processed = true
-		}
-	}
-
-	if pt, ok := ft.(*types.Pointer); ok && !processed {
-		if nt, ok := pt.Elem().(*types.Named); ok {
-			if _, ok := nt.Underlying().(*types.Struct); ok && g.isTargetPackage(nt.Obj().Pkg()) {
-				pubName := gentype.ToPublicName(nt.Obj().Name())
-				typeName = "*" + pubName
+			structInfo, err = g.processStruct(field, field.Named, typeName, false)
+			if err != nil {
+				return nil, err
+			}
 
-				structInfo = g.processStruct(ctx, nt, nt.Underlying().(*types.Struct), pubName, false)
-				processed = true
+			if field.IsPointer {
+				typeName = "*" + typeName
+				isPointer = true
+				structInfo.IsPointerTarget = true
+			}
+		case field.Named == nil && !field.IsPointer:
+			structInfo, err = g.processStruct(field, g.anonStructToNamed(field, targetStructName), "", true)
+			if err != nil {
+				return nil, err
 			}
 		}
 	}
 
-	if stt, ok := ft.(*types.Struct); ok && !processed {
-		structInfo = g.processStruct(
-			ctx,
-			g.anonStructToNamed(stt, targetStructName, field),
-			stt, "",
-			true,
-		)
-	}
-
 	return []FieldInfo{{
-		Name:       gentype.ToPrivateName(field.Name()),
-		ExportName: field.Name(),
-		TypeName:   typeName,
-		Comment:    g.getFieldComment(sourceStructName, field.Name(), fieldIndex),
-		IsStruct:   structInfo != nil,
-		StructInfo: structInfo,
-	}}
+		Name:           gentype.ToPrivateName(field.Name),
+		ExportName:     field.Name,
+		TypeName:       typeName,
+		Comment:        g.getFieldComment(field),
+		Path:           field.Path,
+		Default:        field.Default(gentype.ValueTagsYAML(g.OutputOptions.DefaultValueTag)...),
+		Tag:            field.Tag,
+		Tags:           gentype.ParseTags(field.Tag),
+		IsStruct:       structInfo != nil,
+		IsPointer:      isPointer,
+		IsSecret:       field.IsSecret(),
+		ContainsSecret: field.ContainsSecret(),
+		Deprecated:     field.Deprecation(),
+		EnvAliases:     field.EnvAliases(),
+		YAMLAliases:    field.YAMLAliases(),
+		StructInfo:     structInfo,
+	}}, nil
 }
 
-func (g *GoGetter) processAnonymousField(
-	ctx context.Context,
-	field *types.Var,
-	targetStructName string,
-) []FieldInfo {
-	ft := field.Type()
-
-	if nt, ok := ft.(*types.Named); ok {
-		if _, ok := nt.Underlying().(*types.Struct); ok {
-			g.processStruct(ctx, nt, nt.Underlying().(*types.Struct), "", true)
-
-			embedded := g.collectedStructs[gentype.ToPublicName(nt.Obj().Name())]
-
-			return embedded.Fields
-		}
+func (g *GoGetter) processAnonymousField(field *gentype.Node, targetStructName string) ([]FieldInfo, error) {
+	if field.Kind != gentype.NodeKindStruct || field.IsPointer {
+		return nil, nil
 	}
 
-	if stt, ok := ft.(*types.Struct); ok {
-		named := g.anonStructToNamed(stt, targetStructName, field)
-
-		g.processStruct(
-			ctx,
-			g.anonStructToNamed(stt, targetStructName, field),
-			stt,
-			"",
-			true,
-		)
-
-		embedded := g.collectedStructs[gentype.ToPublicName(named.Obj().Name())]
+	named := field.Named
+	if named == nil {
+		named = g.anonStructToNamed(field, targetStructName)
+	}
 
-		return embedded.Fields
+	embedded, err := g.processStruct(field, named, "", true)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return embedded.Fields, nil
 }
 
-func (g *GoGetter) anonStructToNamed(st *types.Struct, targetStructName string, field *types.Var) *types.Named {
-	anonName := fmt.Sprintf("%s%s", targetStructName, gentype.ToCamel(field.Name()))
+func (g *GoGetter) anonStructToNamed(field *gentype.Node, targetStructName string) *types.Named {
+	anonName := fmt.Sprintf("%s%s", targetStructName, gentype.ToCamel(field.Name))
 
 	return types.NewNamed(types.NewTypeName(
 		token.NoPos, nil, anonName, nil,
-	), st, nil)
+	), field.Type.Underlying(), nil)
 }
 
+// getImports returns the sorted import specs,
+// the standard library imports are separated from the others with an empty string.
 func (g *GoGetter) getImports() []string {
-	imports := make([]string, 0, len(g.collectedImports))
+	var std, others []string
+
+	for imp, qualifier := range g.collectedImports {
+		spec := strconv.Quote(imp)
+		if qualifier != path.Base(imp) {
+			spec = qualifier + " " + spec
+		}
 
-	for imp := range g.collectedImports {
-		imports = append(imports, imp)
+		if gentype.IsStdPackage(imp) {
+			std = append(std, spec)
+		} else {
+			others = append(others, spec)
+		}
 	}
 
-	slices.Sort(imports)
+	slices.Sort(std)
+	slices.Sort(others)
+
+	if len(std) == 0 || len(others) == 0 {
+		return append(std, others...)
+	}
 
-	return imports
+	return slices.Concat(std, []string{""}, others)
+}
+
+// publicStructName returns a name of the generated struct for the named source struct.
+func (g *GoGetter) publicStructName(named *types.Named) string {
+	return gentype.ToPublicName(gentype.TypeInstanceName(named))
 }
 
 //nolint:cyclop
@@ -183,16 +182,11 @@ func (g *GoGetter) formatTypeName(t types.Type) string {
 	case *types.Basic:
 		return tt.Name()
 	case *types.Named:
-		obj := tt.Obj()
-		pkg := obj.Pkg()
-
-		if pkg != nil && pkg.Name() != "main" && !g.isTargetPackage(pkg) {
-			g.registerImport(pkg.Path())
-
-			return pkg.Name() + "." + obj.Name()
-		}
-
-		return obj.Name()
+		return g.formatObjectName(tt.Obj()) + g.formatTypeArgs(tt.TypeArgs())
+	case *types.Alias:
+		return g.formatObjectName(tt.Obj()) + g.formatTypeArgs(tt.TypeArgs())
+	case *types.TypeParam:
+		return tt.Obj().Name()
 	case *types.Slice:
 		return "[]" + g.formatTypeName(tt.Elem())
 	case *types.Pointer:
@@ -210,12 +204,66 @@ func (g *GoGetter) formatTypeName(t types.Type) string {
 	}
 }
 
-func (g *GoGetter) registerImport(imp string) {
-	if g.isTargetPackage(imp) {
-		return
+func (g *GoGetter) formatObjectName(obj *types.TypeName) string {
+	pkg := obj.Pkg()
+
+	if pkg == nil || g.isSourcePackage(pkg) || g.isTargetPackage(pkg) {
+		return obj.Name()
+	}
+
+	return g.registerImport(pkg) + "." + obj.Name()
+}
+
+func (g *GoGetter) formatTypeArgs(args *types.TypeList) string {
+	if args.Len() == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, args.Len())
+
+	for i := 0; i < args.Len(); i++ {
+		names = append(names, g.formatTypeName(args.At(i)))
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// registerImport adds the package to the imports list
+// and returns the qualifier to use in the generated code.
+// Packages with the same name are imported with an alias.
+func (g *GoGetter) registerImport(pkg *types.Package) string {
+	if qualifier, ok := g.collectedImports[pkg.Path()]; ok {
+		return qualifier
+	}
+
+	qualifier := pkg.Name()
+
+	for i := 2; g.isQualifierTaken(qualifier); i++ {
+		qualifier = pkg.Name() + strconv.Itoa(i)
+	}
+
+	g.collectedImports[pkg.Path()] = qualifier
+
+	return qualifier
+}
+
+func (g *GoGetter) isQualifierTaken(qualifier string) bool {
+	if qualifier == g.OutputOptions.TargetPackageName {
+		return true
 	}
 
-	g.collectedImports[imp] = struct{}{}
+	for _, taken := range g.collectedImports {
+		if taken == qualifier {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSourcePackage checks if package is the package of the source struct.
+func (g *GoGetter) isSourcePackage(pkg *types.Package) bool {
+	return pkg.Path() == g.Source.Package.Types.Path()
 }
 
 func (g *GoGetter) isTargetPackage(pkg any) bool {
@@ -224,11 +272,20 @@ func (g *GoGetter) isTargetPackage(pkg any) bool {
 	return pkgName == g.OutputOptions.TargetPackageName
 }
 
-func (g *GoGetter) getFieldComment(sourceStructName string, exportName string, fieldIndex int) string {
-	comment := g.Source.GetStructFieldComment(sourceStructName, fieldIndex)
+func (g *GoGetter) getFieldComment(field *gentype.Node) string {
+	comment := field.Comment
+
+	if comment != "" && !strings.HasPrefix(comment, field.Name) {
+		comment = field.Name + " " + comment
+	}
+
+	if deprecated := field.DeprecatedComment(); deprecated != "" {
+		// Deprecation notice is a separate paragraph by the Go doc convention.
+		if comment == "" {
+			comment = field.Name + " is deprecated."
+		}
 
-	if comment != "" && !strings.HasPrefix(comment, exportName) {
-		comment = exportName + " " + comment
+		comment += "\n\n" + deprecated
 	}
 
 	return comment
@@ -4,35 +4,136 @@ import (
 	"embed"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"text/template"
+	"text/template/parse"
 )
 
 //go:embed *.go.tpl
 var embeddedTemplates embed.FS
 
-var templateFuncs = template.FuncMap{}
+var templateFuncs = template.FuncMap{
+	"comment": goComment,
+}
+
+const (
+	rootTemplateName    = "template.go.tpl"
+	fixtureTemplateName = "fixture.go.tpl"
+	flagsTemplateName   = "flags.go.tpl"
+	helpersTemplateName = "helpers.go.tpl"
+)
 
+// tplData is a root object of the Go getter template.
+// Its fields are the stable contract for the custom templates.
 type tplData struct {
+	// Structs are the generated structs, keyed by the struct name.
 	Structs map[string]*StructInfo
 
+	// PackageName is a target package name.
 	PackageName string
-	Version     string
-	Imports     []string
 
+	// Version is a configen version.
+	Version string
+
+	// Imports are the import specs, e.g. `"time"` or `htmltemplate "html/template"`,
+	// the standard library imports are separated from the others with an empty string.
+	Imports []string
+
+	// TargetStructName is a name of the generated root struct.
 	TargetStructName string
+
+	// SourceStructName is a name of the source root struct.
+	SourceStructName string
+
+	// WithInterfaces enables the `<Struct>Reader` interfaces generation.
+	WithInterfaces bool
+
+	// WithMocks enables the `<Struct>Mock` implementations generation.
+	WithMocks bool
+
+	// Store is the `<Struct>Store` snapshot holder of the root struct, nil if disabled.
+	Store *StoreInfo
+
+	// Diff enables the `Equal` and `Diff` methods generation, nil if disabled.
+	Diff *DiffInfo
+
+	// Aliases are the resolvers of the deprecated keys aliases, nil if there are no `aliases` tags.
+	Aliases *AliasesInfo
+}
+
+// DiffInfo describes the generated `Equal` and `Diff` methods.
+type DiffInfo struct {
+	// ReflectPkg is a qualifier of the reflect package.
+	ReflectPkg string
+}
+
+// StoreInfo describes the generated `<Struct>Store` snapshot holder.
+type StoreInfo struct {
+	// Name is a name of the store struct.
+	Name string
+
+	// TargetStructName is a name of the generated root struct.
+	TargetStructName string
+
+	// SourceStructName is a name of the source root struct.
 	SourceStructName string
+
+	// SyncPkg and AtomicPkg are the qualifiers of the sync and sync/atomic packages.
+	SyncPkg   string
+	AtomicPkg string
 }
 
-func executeTemplate(w io.Writer, data tplData) error {
+// executeTemplate renders the embedded template, overridden by the custom template if path is given.
+// Custom template replaces the whole embedded template if it has a content outside the `define` blocks,
+// otherwise its `define` blocks replace the embedded ones (`header`, `struct`, `getters`,
+// `constructor`, `pointerConstructor`, `interface`, `mock`, `store`, `diff`, `fieldChange`, `aliases`).
+func executeTemplate(w io.Writer, data tplData, customPath string) error {
 	tpl := template.New("gogetter")
 	tpl.Funcs(templateFuncs)
 
-	tpl, err := tpl.ParseFS(embeddedTemplates, "*.go.tpl")
+	tpl, err := tpl.ParseFS(embeddedTemplates, rootTemplateName)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	name := rootTemplateName
+
+	if customPath != "" {
+		content, err := os.ReadFile(customPath)
+		if err != nil {
+			return fmt.Errorf("read template: %w", err)
+		}
+
+		customName := filepath.Base(customPath)
+
+		if _, err := tpl.New(customName).Parse(string(content)); err != nil {
+			return fmt.Errorf("parse template %s: %w", customPath, err)
+		}
+
+		if custom := tpl.Lookup(customName); custom != nil && custom.Tree != nil && !parse.IsEmptyTree(custom.Tree.Root) {
+			name = customName
+		}
+	}
+
+	if err := tpl.ExecuteTemplate(w, name, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
+
+// executeHelpersTemplate renders the embedded template using the `helpers` define block.
+func executeHelpersTemplate(w io.Writer, name string, data any) error {
+	tpl := template.New(name)
+	tpl.Funcs(templateFuncs)
+
+	tpl, err := tpl.ParseFS(embeddedTemplates, name, helpersTemplateName)
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
 	}
 
-	if err := tpl.ExecuteTemplate(w, "template.go.tpl", data); err != nil {
+	if err := tpl.ExecuteTemplate(w, name, data); err != nil {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
@@ -1,3 +1,44 @@
+{{- template "header" . }}
+
+{{ range $name, $st := .Structs }}
+{{ template "struct" $st }}
+
+{{ template "getters" $st }}
+
+{{ if not $st.IsAnonymous }}
+{{ template "constructor" $st }}
+{{ end }}
+
+{{ if $st.IsPointerTarget }}
+{{ template "pointerConstructor" $st }}
+{{ end }}
+
+{{ if and $.WithInterfaces (not $st.IsAnonymous) }}
+{{ template "interface" $st }}
+{{ end }}
+
+{{ if and $.WithMocks (not $st.IsAnonymous) }}
+{{ template "mock" $st }}
+{{ end }}
+
+{{ if and $.Diff (not $st.IsAnonymous) }}
+{{ template "diff" $st }}
+{{ end }}
+{{ end }}
+
+{{ if .Diff }}
+{{ template "fieldChange" .Diff }}
+{{ end }}
+
+{{ if .Store }}
+{{ template "store" .Store }}
+{{ end }}
+
+{{ if .Aliases }}
+{{ template "aliases" .Aliases }}
+{{ end }}
+
+{{- define "header" -}}
 // Package {{ .PackageName }} contains configuration read-only provider.
 //
 // Code generated by github.com/kukymbr/configen; DO NOT EDIT.
@@ -7,14 +48,15 @@ package {{ .PackageName }}
 {{ if len .Imports }}
 import(
 {{- range .Imports }}
-    "{{ . }}"
+    {{ . }}
 {{- end }}
 )
 {{ end }}
+{{- end }}
 
-{{ range $name, $st := .Structs }}
-type {{ $st.Name }} struct {
-{{- range $st.Fields }}
+{{- define "struct" -}}
+type {{ .Name }} struct {
+{{- range .Fields }}
     {{- if and .IsStruct .StructInfo.IsAnonymous }}
         {{ .Name }} struct {
             {{- range .StructInfo.Fields }}
@@ -28,9 +70,14 @@ type {{ $st.Name }} struct {
 
     origin any
 }
+{{- end }}
 
-{{ range $fieldIndex, $field := $st.Fields }}
-{{ if $field.Comment -}}// {{- $field.Comment -}}{{- end -}}{{- if not (and $field.IsStruct $field.StructInfo.IsAnonymous) -}}
+{{- define "getters" -}}
+{{ $st := . }}
+{{- range $fieldIndex, $field := $st.Fields }}
+{{ if $field.Comment }}{{ comment $field.Comment }}
+{{ end -}}
+{{- if not (and $field.IsStruct $field.StructInfo.IsAnonymous) -}}
     func (c {{ $st.Name }}) {{ $field.ExportName }}() {{ $field.TypeName }} {
 {{- else -}}
     func (c {{ $st.Name }}) {{ $field.ExportName }}() struct {
@@ -42,14 +89,18 @@ type {{ $st.Name }} struct {
 	return c.{{ $field.Name }}
 }
 {{ end }}
+{{- end }}
 
-{{ if not $st.IsAnonymous }}
+{{- define "constructor" -}}
+{{ $st := . -}}
 // New{{ $st.Name }} is a constructor converting {{ $st.SourceStructName }} into the {{ $st.Name }}.
 func New{{ $st.Name }}(dto {{ $st.SourceStructName }}) {{ $st.Name }} {
 	return {{ $st.Name }}{
 		{{- range $fieldIndex, $field := $st.Fields }}
             {{- if $field.IsStruct -}}
-                {{- if not $field.StructInfo.IsAnonymous }}
+                {{- if $field.IsPointer }}
+                    {{ $field.Name }}: New{{ $field.StructInfo.Name }}Ptr(dto.{{ $field.ExportName }}),
+                {{- else if not $field.StructInfo.IsAnonymous }}
                     {{ $field.Name }}: New{{ $field.TypeName }}(dto.{{ $field.ExportName }}),
                 {{- else }}
                     {{ $field.Name }}: struct {
@@ -70,5 +121,308 @@ func New{{ $st.Name }}(dto {{ $st.SourceStructName }}) {{ $st.Name }} {
 		origin: dto,
 	}
 }
+{{- end }}
+
+{{- define "pointerConstructor" -}}
+// New{{ .Name }}Ptr is a constructor converting *{{ .SourceStructName }} into the *{{ .Name }}.
+func New{{ .Name }}Ptr(dto *{{ .SourceStructName }}) *{{ .Name }} {
+	if dto == nil {
+		return nil
+	}
+
+	v := New{{ .Name }}(*dto)
+
+	return &v
+}
+{{- end }}
+
+{{- define "fieldType" -}}
+{{- if and .IsStruct .StructInfo.IsAnonymous -}}
+    struct {
+    {{- range .StructInfo.Fields }}
+        {{ .Name }} {{ .TypeName }}
+    {{- end }}
+    }
+{{- else -}}
+    {{ .TypeName }}
+{{- end -}}
+{{- end }}
+
+{{- define "interface" -}}
+// {{ .Name }}Reader is an interface of the {{ .Name }} getters.
+type {{ .Name }}Reader interface {
+{{- range .Fields }}
+    {{ .ExportName }}() {{ template "fieldType" . }}
+{{- end }}
+}
+
+var _ {{ .Name }}Reader = {{ .Name }}{}
+{{- end }}
+
+{{- define "mock" -}}
+{{ $st := . -}}
+// {{ $st.Name }}Mock is a {{ $st.Name }}Reader implementation with the settable values, e.g. for the tests.
+type {{ $st.Name }}Mock struct {
+{{- range $st.Fields }}
+    {{ .ExportName }}Value {{ template "fieldType" . }}
+{{- end }}
+}
+
+var _ {{ $st.Name }}Reader = (*{{ $st.Name }}Mock)(nil)
+{{ range $field := $st.Fields }}
+func (m *{{ $st.Name }}Mock) {{ $field.ExportName }}() {{ template "fieldType" $field }} {
+	return m.{{ $field.ExportName }}Value
+}
 {{ end }}
-{{ end }}
+{{- end }}
+
+{{- define "store" -}}
+{{ $s := . -}}
+// {{ $s.Name }} is a concurrency-safe holder of the {{ $s.TargetStructName }} snapshot, e.g. for the config hot reload.
+type {{ $s.Name }} struct {
+	value {{ $s.AtomicPkg }}.Pointer[{{ $s.TargetStructName }}]
+
+	// swapMu serializes the swaps with their notifications, mu guards the subscribers.
+	swapMu      {{ $s.SyncPkg }}.Mutex
+	mu          {{ $s.SyncPkg }}.Mutex
+	subscribers []*func(old, new {{ $s.TargetStructName }})
+}
+
+// New{{ $s.Name }} creates the {{ $s.Name }} holding the given value.
+// Zero {{ $s.Name }} holds the zero {{ $s.TargetStructName }} value.
+func New{{ $s.Name }}(value {{ $s.TargetStructName }}) *{{ $s.Name }} {
+	s := &{{ $s.Name }}{}
+	s.value.Store(&value)
+
+	return s
+}
+
+// Load returns the current {{ $s.TargetStructName }} snapshot.
+func (s *{{ $s.Name }}) Load() {{ $s.TargetStructName }} {
+	return s.deref(s.value.Load())
+}
+
+// Swap replaces the current {{ $s.TargetStructName }} snapshot and returns the previous one.
+// Subscribers are called synchronously in the caller goroutine after the value is replaced.
+// Concurrent swaps wait for the previous notifications, so the subscribers get the swaps in order
+// and must not call Swap or Reload themselves.
+func (s *{{ $s.Name }}) Swap(value {{ $s.TargetStructName }}) {{ $s.TargetStructName }} {
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+
+	old := s.deref(s.value.Swap(&value))
+
+	s.mu.Lock()
+	subscribers := append(s.subscribers[:0:0], s.subscribers...)
+	s.mu.Unlock()
+
+	for _, fn := range subscribers {
+		(*fn)(old, value)
+	}
+
+	return old
+}
+
+// Reload loads the {{ $s.SourceStructName }} with the given loader function and swaps the current snapshot.
+// Current snapshot is kept if loader fails.
+// The store doesn't watch the config files, Reload is called by the application, e.g. on SIGHUP.
+func (s *{{ $s.Name }}) Reload(load func() ({{ $s.SourceStructName }}, error)) error {
+	dto, err := load()
+	if err != nil {
+		return err
+	}
+
+	s.Swap(New{{ $s.TargetStructName }}(dto))
+
+	return nil
+}
+
+// Subscribe registers the function called on each snapshot swap.
+// Returned function cancels the subscription.
+func (s *{{ $s.Name }}) Subscribe(fn func(old, new {{ $s.TargetStructName }})) (unsubscribe func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub := &fn
+	s.subscribers = append(s.subscribers, sub)
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i, registered := range s.subscribers {
+			if registered == sub {
+				s.subscribers = append(s.subscribers[:i:i], s.subscribers[i+1:]...)
+
+				break
+			}
+		}
+	}
+}
+
+func (s *{{ $s.Name }}) deref(value *{{ $s.TargetStructName }}) {{ $s.TargetStructName }} {
+	if value == nil {
+		return {{ $s.TargetStructName }}{}
+	}
+
+	return *value
+}
+{{- end }}
+
+{{- define "diff" -}}
+{{ $st := . -}}
+// Equal checks if the {{ $st.Name }} values are equal.
+func (c {{ $st.Name }}) Equal(other {{ $st.Name }}) bool {
+	return len(c.Diff(other)) == 0
+}
+
+// Diff returns the fields changed in the other {{ $st.Name }},
+// values of the secret fields and of the lists, maps and structs containing them are masked.
+func (c {{ $st.Name }}) Diff(other {{ $st.Name }}) []FieldChange {
+	return c.diff("", other)
+}
+
+func (c {{ $st.Name }}) diff(prefix string, other {{ $st.Name }}) []FieldChange {
+	var changes []FieldChange
+{{ range $field := $st.Fields }}
+{{- if and $field.IsStruct (not $field.IsSecret) $field.StructInfo.IsAnonymous }}
+	{{- range $sub := $field.StructInfo.Fields }}
+	changes = appendFieldChange(changes, prefix+"{{ $field.ExportName }}.{{ $sub.ExportName }}", c.{{ $field.Name }}.{{ $sub.Name }}, other.{{ $field.Name }}.{{ $sub.Name }}, {{ $sub.ContainsSecret }})
+	{{- end }}
+{{- else if and $field.IsStruct (not $field.IsSecret) $field.IsPointer }}
+	if c.{{ $field.Name }} != nil && other.{{ $field.Name }} != nil {
+		changes = append(changes, c.{{ $field.Name }}.diff(prefix+"{{ $field.ExportName }}.", *other.{{ $field.Name }})...)
+	} else {
+		changes = appendFieldChange(changes, prefix+"{{ $field.ExportName }}", c.{{ $field.Name }}, other.{{ $field.Name }}, {{ $field.ContainsSecret }})
+	}
+{{- else if and $field.IsStruct (not $field.IsSecret) }}
+	changes = append(changes, c.{{ $field.Name }}.diff(prefix+"{{ $field.ExportName }}.", other.{{ $field.Name }})...)
+{{- else }}
+	changes = appendFieldChange(changes, prefix+"{{ $field.ExportName }}", c.{{ $field.Name }}, other.{{ $field.Name }}, {{ $field.ContainsSecret }})
+{{- end }}
+{{- end }}
+
+	return changes
+}
+{{- end }}
+
+{{- define "fieldChange" -}}
+// FieldChange is a config field changed between two values of the generated struct.
+type FieldChange struct {
+	// Path is a field path from the compared struct, e.g. `API.Port`.
+	Path string
+
+	// Old is a field value before the change.
+	Old any
+
+	// New is a field value after the change.
+	New any
+}
+
+// FieldChangeSecretMask replaces the values of the secret fields in the FieldChange.
+const FieldChangeSecretMask = "******"
+
+func appendFieldChange(changes []FieldChange, path string, old, new any, isSecret bool) []FieldChange {
+	if {{ .ReflectPkg }}.DeepEqual(old, new) {
+		return changes
+	}
+
+	if isSecret {
+		old, new = FieldChangeSecretMask, FieldChangeSecretMask
+	}
+
+	return append(changes, FieldChange{Path: path, Old: old, New: new})
+}
+{{- end }}
+
+
+{{- define "aliases" -}}
+{{ $a := . -}}
+// Resolve{{ $a.RootStructName }}EnvAliases sets the dotenv variables from their deprecated aliases
+// if the variables are not set, warn is called for each used alias.
+// Call it before loading the config from the environment.
+func Resolve{{ $a.RootStructName }}EnvAliases(warn func(alias, name string)) error {
+	aliases := []struct {
+		name    string
+		aliases []string
+	}{
+	{{- range $a.Env }}
+		{ {{- printf "%q" .Name }}, []string{ {{- range $i, $alias := .Aliases }}{{ if $i }}, {{ end }}{{ printf "%q" $alias }}{{ end }}}},
+	{{- end }}
+	}
+
+	for _, a := range aliases {
+		if _, ok := {{ $a.OsPkg }}.LookupEnv(a.name); ok {
+			continue
+		}
+
+		for _, alias := range a.aliases {
+			value, ok := {{ $a.OsPkg }}.LookupEnv(alias)
+			if !ok {
+				continue
+			}
+
+			if err := {{ $a.OsPkg }}.Setenv(a.name, value); err != nil {
+				return err
+			}
+
+			if warn != nil {
+				warn(alias, a.name)
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// Resolve{{ $a.RootStructName }}YAMLAliases renames the deprecated YAML keys aliases in the decoded values
+// if the keys are not set, warn is called for each used alias with the full keys paths.
+// Call it on the values decoded from the YAML into the `map[string]any` before decoding them into the struct.
+func Resolve{{ $a.RootStructName }}YAMLAliases(values map[string]any, warn func(alias, key string)) {
+	aliases := []struct {
+		parent  []string
+		prefix  string
+		key     string
+		aliases []string
+	}{
+	{{- range $a.YAML }}
+		{ {{- if .Parent }}[]string{ {{- range $i, $p := .Parent }}{{ if $i }}, {{ end }}{{ printf "%q" $p }}{{ end }}}{{ else }}nil{{ end }}, {{ printf "%q" .KeyPrefix }}, {{ printf "%q" .Name }}, []string{ {{- range $i, $alias := .Aliases }}{{ if $i }}, {{ end }}{{ printf "%q" $alias }}{{ end }}}},
+	{{- end }}
+	}
+
+	for _, a := range aliases {
+		mapping := values
+		for _, key := range a.parent {
+			if mapping, _ = mapping[key].(map[string]any); mapping == nil {
+				break
+			}
+		}
+
+		if mapping == nil {
+			continue
+		}
+
+		if _, ok := mapping[a.key]; ok {
+			continue
+		}
+
+		for _, alias := range a.aliases {
+			value, ok := mapping[alias]
+			if !ok {
+				continue
+			}
+
+			mapping[a.key] = value
+			delete(mapping, alias)
+
+			if warn != nil {
+				warn(a.prefix+alias, a.prefix+a.key)
+			}
+
+			break
+		}
+	}
+}
+{{- end }}
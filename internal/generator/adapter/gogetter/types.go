@@ -1,18 +1,82 @@
 package gogetter
 
+import "strings"
+
+// StructInfo is a generated struct in the Go getter template.
+// Fields of the StructInfo and the FieldInfo are the stable contract for the custom templates.
 type StructInfo struct {
-	Name             string
+	// Name is a name of the generated struct.
+	Name string
+
+	// SourceStructName is a source struct type name, e.g. `apiConfig` or `optional[time.Duration]`.
 	SourceStructName string
-	Doc              string
-	IsAnonymous      bool
-	Fields           []FieldInfo
+
+	// Doc is a source struct doc comment.
+	Doc string
+
+	// IsAnonymous is set for the anonymous and embedded structs, which are not generated separately.
+	IsAnonymous bool
+
+	// IsPointerTarget is set if struct is referenced by a pointer field.
+	IsPointerTarget bool
+
+	// Fields are the generated struct fields, embedded structs fields are inlined.
+	Fields []FieldInfo
 }
 
+// FieldInfo is a field of the generated struct in the Go getter template.
 type FieldInfo struct {
-	Name       string
+	// Name is a name of the generated struct field, e.g. `reqTTL`.
+	Name string
+
+	// ExportName is a name of the source struct field and the getter method, e.g. `ReqTTL`.
 	ExportName string
-	TypeName   string
-	Comment    string
-	IsStruct   bool
+
+	// TypeName is a type of the generated struct field.
+	TypeName string
+
+	// Comment is a getter doc comment, starting with the ExportName.
+	Comment string
+
+	// Path is a source field names path from the root struct, e.g. `["API", "ReqTTL"]`.
+	// For the structs used in several fields, the path of the first occurrence is used.
+	Path []string
+
+	// Default is a default value of the field from the value tags, empty if not defined.
+	Default string
+
+	// Tag is a raw source field tag.
+	Tag string
+
+	// Tags are the source field tags values, keyed by the tag name.
+	Tags map[string]string
+
+	// IsStruct is set if StructInfo is defined for the field.
+	IsStruct bool
+
+	// IsPointer is set for the pointers to the generated structs.
+	IsPointer bool
+
+	// IsSecret is set for the fields marked with the `secret:"true"` tag.
+	IsSecret bool
+
+	// ContainsSecret is set if the field is a secret or its value contains the secret fields.
+	ContainsSecret bool
+
+	// Deprecated is a deprecation message of the `deprecated` tag, empty if field is not deprecated.
+	Deprecated string
+
+	// EnvAliases are the old dotenv variables names of the field without the prefix from the `envAliases` tag.
+	EnvAliases []string
+
+	// YAMLAliases are the old YAML keys of the field from the `yamlAliases` tag.
+	YAMLAliases []string
+
+	// StructInfo is a generated struct of the field, nil for the non-struct fields.
 	StructInfo *StructInfo
 }
+
+// PathString returns the field path joined with the dot, e.g. `API.ReqTTL`.
+func (f FieldInfo) PathString() string {
+	return strings.Join(f.Path, ".")
+}
@@ -5,12 +5,6 @@ import (
 	"strings"
 )
 
-func packageNameFromID(id string) string {
-	parts := strings.Split(id, "/")
-
-	return parts[len(parts)-1]
-}
-
 func docComment(st *ast.StructType) string {
 	if st.Fields == nil {
 		return ""
@@ -24,3 +18,14 @@ func docComment(st *ast.StructType) string {
 
 	return ""
 }
+
+// goComment converts the text into the Go line comments.
+func goComment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,122 @@
+package gogetter
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// valueExprs renders the Go expressions of the raw tag values
+// for the generated code, using the prefixed helper functions.
+type valueExprs struct {
+	*GoGetter
+
+	helpersPrefix string
+	usedHelpers   map[string]bool
+}
+
+func newValueExprs(g *GoGetter) valueExprs {
+	return valueExprs{
+		GoGetter:    g,
+		usedHelpers: make(map[string]bool),
+	}
+}
+
+// valueExpr returns the Go expression of the raw value,
+// empty string if value of the type can't be converted.
+//
+//nolint:cyclop,funlen
+func (g *valueExprs) valueExpr(t types.Type, raw string) (string, error) {
+	quoted := strconv.Quote(raw)
+
+	if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil {
+		switch named.Obj().Pkg().Path() + "." + named.Obj().Name() {
+		case "time.Duration":
+			if _, err := time.ParseDuration(raw); err != nil {
+				return "", fmt.Errorf("invalid duration %q: %w", raw, err)
+			}
+
+			return g.helper("Must") + "(" + g.registerImport(named.Obj().Pkg()) + ".ParseDuration(" + quoted + "))", nil
+		case "net/url.URL":
+			return "*" + g.urlExpr(quoted), nil
+		}
+	}
+
+	switch tt := types.Unalias(t).(type) {
+	case *types.Pointer:
+		if named, ok := types.Unalias(tt.Elem()).(*types.Named); ok && named.Obj().Pkg() != nil &&
+			named.Obj().Pkg().Path() == "net/url" && named.Obj().Name() == "URL" {
+			return g.urlExpr(quoted), nil
+		}
+
+		elem, err := g.valueExpr(tt.Elem(), raw)
+		if err != nil || elem == "" {
+			return "", err
+		}
+
+		return g.helper("Ptr") + "[" + g.formatTypeName(tt.Elem()) + "](" + elem + ")", nil
+	case *types.Slice:
+		elems := make([]string, 0)
+
+		for _, part := range strings.Split(raw, ",") {
+			elem, err := g.valueExpr(tt.Elem(), strings.TrimSpace(part))
+			if err != nil || elem == "" {
+				return "", err
+			}
+
+			elems = append(elems, elem)
+		}
+
+		return g.formatTypeName(t) + "{" + strings.Join(elems, ", ") + "}", nil
+	}
+
+	if gentype.IsTextUnmarshaler(t) {
+		return g.helper("Text") + "[" + g.formatTypeName(t) + "](" + quoted + ")", nil
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "", nil
+	}
+
+	var err error
+
+	switch info := basic.Info(); {
+	case info&types.IsBoolean != 0:
+		_, err = strconv.ParseBool(raw)
+	case info&types.IsInteger != 0:
+		_, err = strconv.ParseInt(raw, 0, 64)
+	case info&types.IsFloat != 0:
+		_, err = strconv.ParseFloat(raw, 64)
+	case info&types.IsString != 0:
+		raw = quoted
+	default:
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid value %q: %w", raw, err)
+	}
+
+	// Named types are converted explicitly to keep the type in the generic helpers and slices.
+	if _, isBasic := types.Unalias(t).(*types.Basic); !isBasic {
+		return g.formatTypeName(t) + "(" + raw + ")", nil
+	}
+
+	return raw, nil
+}
+
+func (g *valueExprs) urlExpr(quoted string) string {
+	return g.helper("Must") + "(" + g.registerImport(types.NewPackage("net/url", "url")) + ".Parse(" + quoted + "))"
+}
+
+// helper returns the name of the helper function and marks it as used.
+func (g *valueExprs) helper(name string) string {
+	g.usedHelpers[name] = true
+
+	return g.helpersPrefix + name
+}
@@ -0,0 +1,82 @@
+// Package plugin implements the external adapters support.
+//
+// Plugin is an executable receiving the Request as JSON on the stdin
+// and writing the Response as JSON to the stdout, similar to the protoc plugins.
+// Non-zero exit code or non-empty Response.Error fails the generation.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+type Plugin struct {
+	gentype.GenericAdapter
+
+	name       string
+	executable string
+}
+
+func New(model *gentype.Model, outputOptions gentype.OutputOptions, name string, executable string) *Plugin {
+	return &Plugin{
+		GenericAdapter: gentype.NewGenericAdapter(model, outputOptions),
+
+		name:       name,
+		executable: executable,
+	}
+}
+
+// LookPath searches for the `configen-gen-<name>` plugin executable in the PATH.
+func LookPath(name string) (string, error) {
+	path, err := exec.LookPath(ExecutablePrefix + name)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: %w", name, err)
+	}
+
+	return path, nil
+}
+
+func (g *Plugin) Generate(ctx context.Context) (gentype.OutputFiles, error) {
+	req, err := json.Marshal(NewRequest(g.name, g.Model, g.OutputOptions))
+	if err != nil {
+		return nil, fmt.Errorf("marshal plugin request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, g.executable)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("run plugin %s: %w: %s", g.executable, err, msg)
+		}
+
+		return nil, fmt.Errorf("run plugin %s: %w", g.executable, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal plugin %s response: %w", g.executable, err)
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	files := make(gentype.OutputFiles, 0, len(resp.Files))
+	for _, file := range resp.Files {
+		files = append(files, []byte(file.Content))
+	}
+
+	return files, nil
+}
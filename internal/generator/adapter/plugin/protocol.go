@@ -0,0 +1,150 @@
+package plugin
+
+import (
+	"go/types"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/kukymbr/configen/internal/version"
+)
+
+// ExecutablePrefix is a name prefix of the plugin executables, e.g. `configen-gen-toml`.
+const ExecutablePrefix = "configen-gen-"
+
+// Request is a plugin input, written as JSON to the plugin stdin.
+type Request struct {
+	// Version is a configen version.
+	Version string `json:"version"`
+
+	// Adapter is a name the plugin is registered with.
+	Adapter string `json:"adapter"`
+
+	// Package is an import path of the source package.
+	Package string `json:"package"`
+
+	// PackageName is a name of the source package.
+	PackageName string `json:"package_name"`
+
+	// StructName is a name of the source struct.
+	StructName string `json:"struct_name"`
+
+	// Doc is a doc comment of the source struct.
+	Doc string `json:"doc,omitempty"`
+
+	// Output are the output options.
+	Output Output `json:"output"`
+
+	// Root is a source struct node.
+	Root *Node `json:"root"`
+}
+
+// Output are the output options passed to the plugin.
+type Output struct {
+	Path              string            `json:"path"`
+	Tag               string            `json:"tag,omitempty"`
+	PrefixTag         string            `json:"prefix_tag,omitempty"`
+	DefaultValueTag   string            `json:"default_value_tag,omitempty"`
+	TargetStructName  string            `json:"target_struct_name,omitempty"`
+	TargetPackageName string            `json:"target_package_name,omitempty"`
+	Params            map[string]string `json:"params,omitempty"`
+}
+
+// Node is a config model node, see gentype.Node.
+type Node struct {
+	Name      string            `json:"name,omitempty"`
+	Path      []string          `json:"path,omitempty"`
+	Type      string            `json:"type"`
+	Kind      string            `json:"kind"`
+	Tag       string            `json:"tag,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Comment   string            `json:"comment,omitempty"`
+	Enum      []string          `json:"enum,omitempty"`
+	Scalar    *Scalar           `json:"scalar,omitempty"`
+	Fields    []*Node           `json:"fields,omitempty"`
+	Elem      *Node             `json:"elem,omitempty"`
+	Embedded  bool              `json:"embedded,omitempty"`
+	Pointer   bool              `json:"pointer,omitempty"`
+	Recursive bool              `json:"recursive,omitempty"`
+}
+
+// Scalar is a definition of the type rendered as a single value.
+type Scalar struct {
+	Type   string `json:"type"`
+	Sample string `json:"sample,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// Response is a plugin output, read as JSON from the plugin stdout.
+type Response struct {
+	// Files are the generated files, a single file at most: it's written to the output path.
+	Files []File `json:"files"`
+
+	// Error is an error message, generation fails if not empty.
+	Error string `json:"error,omitempty"`
+}
+
+// File is a generated file.
+type File struct {
+	Content string `json:"content"`
+}
+
+// NewRequest converts the config model into the plugin request.
+func NewRequest(adapter string, model *gentype.Model, out gentype.OutputOptions) *Request {
+	pkg := model.Source.Package.Types
+
+	return &Request{
+		Version:     version.GetVersion(),
+		Adapter:     adapter,
+		Package:     pkg.Path(),
+		PackageName: pkg.Name(),
+		StructName:  model.Source.RootStructName,
+		Doc:         model.Source.RootStructDoc,
+		Output: Output{
+			Path:              out.Path,
+			Tag:               out.Tag,
+			PrefixTag:         out.PrefixTag,
+			DefaultValueTag:   out.DefaultValueTag,
+			TargetStructName:  out.TargetStructName,
+			TargetPackageName: out.TargetPackageName,
+			Params:            out.Params,
+		},
+		Root: newNode(model.Root, types.RelativeTo(pkg)),
+	}
+}
+
+func newNode(node *gentype.Node, qualifier types.Qualifier) *Node {
+	if node == nil {
+		return nil
+	}
+
+	n := &Node{
+		Name:      node.Name,
+		Path:      node.Path,
+		Type:      types.TypeString(node.Type, qualifier),
+		Kind:      node.Kind.String(),
+		Tag:       node.Tag,
+		Comment:   node.Comment,
+		Enum:      node.Enum,
+		Elem:      newNode(node.Elem, qualifier),
+		Embedded:  node.IsEmbedded,
+		Pointer:   node.IsPointer,
+		Recursive: node.IsRecursive,
+	}
+
+	if node.Tag != "" {
+		n.Tags = gentype.ParseTags(node.Tag)
+	}
+
+	if node.Scalar != nil {
+		n.Scalar = &Scalar{
+			Type:   node.Scalar.Type,
+			Sample: node.Scalar.Sample,
+			Format: node.Scalar.Format,
+		}
+	}
+
+	for _, field := range node.Fields {
+		n.Fields = append(n.Fields, newNode(field, qualifier))
+	}
+
+	return n
+}
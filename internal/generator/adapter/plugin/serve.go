@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// GenerateFunc generates the files from the plugin request.
+type GenerateFunc func(req *Request) ([]File, error)
+
+// Serve runs the plugin: reads the request from the stdin and writes the response to the stdout.
+func Serve(generate GenerateFunc) error {
+	return Handle(os.Stdin, os.Stdout, generate)
+}
+
+// Handle reads the request from the reader and writes the response to the writer.
+// Generation error is returned to the configen in the Response.Error.
+func Handle(r io.Reader, w io.Writer, generate GenerateFunc) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("decode plugin request: %w", err)
+	}
+
+	var resp Response
+
+	files, err := generate(&req)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Files = files
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("encode plugin response: %w", err)
+	}
+
+	return nil
+}
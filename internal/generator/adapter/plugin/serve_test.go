@@ -0,0 +1,65 @@
+package plugin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kukymbr/configen/internal/generator/adapter/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    string
+		Generate plugin.GenerateFunc
+		Expected plugin.Response
+		IsError  bool
+	}{
+		{
+			Name:  "files generated",
+			Input: `{"struct_name":"config","root":{"kind":"struct","fields":[{"name":"Host","kind":"scalar"}]}}`,
+			Generate: func(req *plugin.Request) ([]plugin.File, error) {
+				return []plugin.File{{Content: req.StructName + "." + req.Root.Fields[0].Name}}, nil
+			},
+			Expected: plugin.Response{Files: []plugin.File{{Content: "config.Host"}}},
+		},
+		{
+			Name:  "generation failed",
+			Input: `{"struct_name":"config"}`,
+			Generate: func(_ *plugin.Request) ([]plugin.File, error) {
+				return nil, errors.New("failed")
+			},
+			Expected: plugin.Response{Error: "failed"},
+		},
+		{
+			Name:    "invalid request",
+			Input:   `{`,
+			IsError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := plugin.Handle(strings.NewReader(test.Input), &out, test.Generate)
+			if test.IsError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var resp plugin.Response
+
+			require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+			assert.Equal(t, test.Expected, resp)
+		})
+	}
+}
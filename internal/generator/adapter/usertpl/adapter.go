@@ -0,0 +1,62 @@
+// Package usertpl renders the user-supplied text/template against the config model.
+package usertpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// ParamEnvTag is a name of the OutputOptions.Params value with the dotenv variables names tag.
+const ParamEnvTag = gentype.ParamEnvTag
+
+type UserTemplate struct {
+	gentype.GenericAdapter
+}
+
+func New(model *gentype.Model, outputOptions gentype.OutputOptions) *UserTemplate {
+	return &UserTemplate{
+		GenericAdapter: gentype.NewGenericAdapter(model, outputOptions),
+	}
+}
+
+func (g *UserTemplate) Generate(ctx context.Context) (gentype.OutputFiles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if g.OutputOptions.TemplatePath == "" {
+		return nil, errors.New("template path is required")
+	}
+
+	content, err := os.ReadFile(g.OutputOptions.TemplatePath)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+
+	tpl, err := template.New(filepath.Base(g.OutputOptions.TemplatePath)).
+		Funcs(Funcs()).
+		Option("missingkey=error").
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", g.OutputOptions.TemplatePath, err)
+	}
+
+	data, err := g.newData()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("execute template %s: %w", g.OutputOptions.TemplatePath, err)
+	}
+
+	return gentype.OutputFiles{buf.Bytes()}, nil
+}
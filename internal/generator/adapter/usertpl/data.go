@@ -0,0 +1,282 @@
+package usertpl
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/kukymbr/configen/internal/version"
+)
+
+// Data is a root object of the user template.
+type Data struct {
+	// Version is a configen version.
+	Version string
+
+	// Package is an import path of the source package.
+	Package string
+
+	// PackageName is a name of the source package.
+	PackageName string
+
+	// StructName is a name of the source struct.
+	StructName string
+
+	// Doc is a doc comment of the source struct.
+	Doc string
+
+	// Params are the output params.
+	Params map[string]string
+
+	// Fields are the fields of the source struct, embedded structs fields are inlined.
+	Fields []*Field
+
+	// Values are the non-struct fields of all the nesting levels, in the order of declaration.
+	Values []*Field
+}
+
+// Field is a config field in the user template.
+type Field struct {
+	// Name is a Go field name.
+	Name string
+
+	// Path is a Go field names path from the root struct.
+	Path []string
+
+	// Key is a YAML key of the field, empty if the field is skipped.
+	Key string
+
+	// KeyPath is a YAML keys path from the root struct.
+	KeyPath []string
+
+	// EnvName is a full dotenv variable name with the prefixes, empty if not defined.
+	EnvName string
+
+	// Type is a Go type of the field, relative to the source package.
+	Type string
+
+	// Kind is a kind of the field: scalar, struct, list, map or opaque.
+	Kind string
+
+	// Default is a default value from the YAML value tags.
+	Default string
+
+	// EnvDefault is a default value from the dotenv value tags.
+	EnvDefault string
+
+	// Value is the Default or a zero value of the scalar field.
+	Value string
+
+	// EnvValue is the EnvDefault or a zero value of the scalar field.
+	EnvValue string
+
+	// Comment is a field doc comment.
+	Comment string
+
+	// Enum are the allowed values of the enum-like types.
+	Enum []string
+
+	// Format is a format hint of the registered scalar types for the schemas, e.g. `duration` or `uri`.
+	Format string
+
+	// Deprecated is a deprecation message of the `deprecated` tag, empty if field is not deprecated.
+	Deprecated string
+
+	// Aliases are the old YAML keys of the field from the `yamlAliases` tag.
+	Aliases []string
+
+	// EnvAliases are the old dotenv variables names of the field with the prefixes, empty if EnvName is empty.
+	EnvAliases []string
+
+	// Tags are the field tags values, keyed by the tag name.
+	Tags map[string]string
+
+	// Fields are the struct fields, embedded structs fields are inlined.
+	Fields []*Field
+
+	// Elem is a list or map element.
+	Elem *Field
+
+	IsPointer   bool
+	IsRecursive bool
+}
+
+// PathString returns the field path joined with the dot.
+func (f *Field) PathString() string {
+	return strings.Join(f.Path, ".")
+}
+
+// KeyPathString returns the YAML keys path joined with the dot.
+func (f *Field) KeyPathString() string {
+	return strings.Join(f.KeyPath, ".")
+}
+
+func (g *UserTemplate) newData() (*Data, error) {
+	pkg := g.Source.Package.Types
+
+	b := &dataBuilder{
+		out: g.OutputOptions,
+		qualifier: func(p *types.Package) string {
+			if p == pkg {
+				return ""
+			}
+
+			return p.Name()
+		},
+		yamlTag:   valueOrDefault(g.OutputOptions.Tag, gentype.TagYAML),
+		envTag:    valueOrDefault(g.OutputOptions.Params[ParamEnvTag], gentype.TagEnv),
+		prefixTag: valueOrDefault(g.OutputOptions.PrefixTag, gentype.TagEnvPrefix),
+	}
+
+	fields, err := b.collectFields(g.Model.Root, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Data{
+		Version:     version.GetVersion(),
+		Package:     pkg.Path(),
+		PackageName: pkg.Name(),
+		StructName:  g.Source.RootStructName,
+		Doc:         g.Source.RootStructDoc,
+		Params:      g.OutputOptions.Params,
+		Fields:      fields,
+		Values:      b.values,
+	}, nil
+}
+
+type dataBuilder struct {
+	out       gentype.OutputOptions
+	qualifier types.Qualifier
+
+	yamlTag   string
+	envTag    string
+	prefixTag string
+
+	values []*Field
+}
+
+func (b *dataBuilder) collectFields(node *gentype.Node, keyPath []string, envPrefix string) ([]*Field, error) {
+	fields := make([]*Field, 0, len(node.Fields))
+
+	for _, child := range node.Fields {
+		if child.IsEmbedded {
+			if child.Kind != gentype.NodeKindStruct || child.Named == nil {
+				continue
+			}
+
+			embedded, err := b.collectFields(child, keyPath, envPrefix)
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, embedded...)
+
+			continue
+		}
+
+		field, err := b.newField(child, keyPath, envPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (b *dataBuilder) newField(node *gentype.Node, keyPath []string, envPrefix string) (*Field, error) {
+	field := &Field{
+		Name:        node.Name,
+		Path:        node.Path,
+		KeyPath:     keyPath,
+		Type:        types.TypeString(node.Type, b.qualifier),
+		Kind:        node.Kind.String(),
+		Default:     node.Default(gentype.ValueTagsYAML(b.out.DefaultValueTag)...),
+		EnvDefault:  node.Default(gentype.ValueTagsEnv(b.out.DefaultValueTag)...),
+		Comment:     node.Comment,
+		Enum:        node.Enum,
+		Deprecated:  node.Deprecation(),
+		Aliases:     node.YAMLAliases(),
+		Tags:        gentype.ParseTags(node.Tag),
+		IsPointer:   node.IsPointer,
+		IsRecursive: node.RecursiveType() != nil,
+	}
+
+	if node.Name != "" {
+		field.Key = node.Key(b.yamlTag, node.Name)
+		field.KeyPath = append(slices.Clone(keyPath), field.Key)
+
+		if envName := node.Key(b.envTag, ""); envName != "" {
+			field.EnvName = envPrefix + envName
+
+			for _, alias := range node.EnvAliases() {
+				field.EnvAliases = append(field.EnvAliases, envPrefix+alias)
+			}
+		}
+	}
+
+	field.Value, field.EnvValue = field.Default, field.EnvDefault
+
+	if node.Scalar != nil {
+		field.Format = node.Scalar.Format
+	}
+
+	if node.Kind == gentype.NodeKindScalar {
+		var err error
+
+		if field.Value, err = node.Value(field.Default); err != nil {
+			return nil, fmt.Errorf("field %s: %w", node.PathString(), err)
+		}
+
+		if field.EnvValue, err = node.Value(field.EnvDefault); err != nil {
+			return nil, fmt.Errorf("field %s: %w", node.PathString(), err)
+		}
+	}
+
+	if node.Name != "" && node.Kind != gentype.NodeKindStruct {
+		b.values = append(b.values, field)
+	}
+
+	if node.Kind == gentype.NodeKindStruct && !node.IsRecursive {
+		fields, err := b.collectFields(node, field.KeyPath, envPrefix+node.TagValue(b.prefixTag))
+		if err != nil {
+			return nil, err
+		}
+
+		field.Fields = fields
+	}
+
+	if node.Elem != nil {
+		elem, err := b.newElem(node.Elem, field.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		field.Elem = elem
+	}
+
+	return field, nil
+}
+
+// newElem creates the list or map element field, its values are not added to the Data.Values.
+func (b *dataBuilder) newElem(node *gentype.Node, keyPath []string) (*Field, error) {
+	values := b.values
+
+	elem, err := b.newField(node, keyPath, "")
+
+	b.values = values
+
+	return elem, err
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,76 @@
+package usertpl
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// Funcs returns the functions available in the user templates.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"camel":      gentype.ToCamel,
+		"lowerCamel": gentype.ToLowerCamel,
+		"snake":      gentype.ToSnake,
+		"kebab":      gentype.ToKebab,
+		"upperSnake": toUpperSnake,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"replace":    replace,
+		"join":       join,
+		"quote":      strconv.Quote,
+		"squote":     singleQuote,
+		"indent":     indent,
+		"nindent":    newLineIndent,
+		"comment":    comment,
+		"default":    defaultValue,
+	}
+}
+
+func toUpperSnake(s string) string {
+	return strings.ToUpper(gentype.ToSnake(s))
+}
+
+// replace is a strings.ReplaceAll with the pipeline-friendly arguments order.
+func replace(old string, replacement string, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+// join is a strings.Join with the pipeline-friendly arguments order.
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+// singleQuote quotes the string with the single quotes, as in YAML.
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// indent adds the given number of spaces to the beginning of each line.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// newLineIndent is an indent starting with the new line.
+func newLineIndent(spaces int, s string) string {
+	return "\n" + indent(spaces, s)
+}
+
+// comment adds the prefix to the beginning of each line, e.g. `{{ comment "# " .Comment }}`.
+func comment(prefix string, s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// defaultValue returns the fallback if the value is empty.
+func defaultValue(fallback string, value string) string {
+	return valueOrDefault(value, fallback)
+}
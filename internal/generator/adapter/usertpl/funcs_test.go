@@ -0,0 +1,42 @@
+package usertpl
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncs(t *testing.T) {
+	tests := []struct {
+		Template string
+		Expected string
+	}{
+		{Template: `{{ "apiConfig" | camel }}`, Expected: "APIConfig"},
+		{Template: `{{ "APIConfig" | lowerCamel }}`, Expected: "apiConfig"},
+		{Template: `{{ "reqTTL" | snake }}`, Expected: "req_ttl"},
+		{Template: `{{ "reqTTL" | kebab }}`, Expected: "req-ttl"},
+		{Template: `{{ "reqTTL" | upperSnake }}`, Expected: "REQ_TTL"},
+		{Template: `{{ "say \"hi\"" | quote }}`, Expected: `"say \"hi\""`},
+		{Template: `{{ "it's" | squote }}`, Expected: `'it''s'`},
+		{Template: `{{ "a\nb" | indent 2 }}`, Expected: "  a\n  b"},
+		{Template: `key:{{ "a" | nindent 2 }}`, Expected: "key:\n  a"},
+		{Template: `{{ "a\nb" | comment "# " }}`, Expected: "# a\n# b"},
+		{Template: `{{ "" | default "none" }}`, Expected: "none"},
+		{Template: `{{ "a.b" | replace "." "_" }}`, Expected: "a_b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Template, func(t *testing.T) {
+			tpl, err := template.New("test").Funcs(Funcs()).Parse(test.Template)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+
+			require.NoError(t, tpl.Execute(&buf, nil))
+			assert.Equal(t, test.Expected, buf.String())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package yaml
 import (
 	"context"
 	"fmt"
+
 	"github.com/kukymbr/configen/internal/generator/gentype"
 	"gopkg.in/yaml.v3"
 )
@@ -10,22 +11,90 @@ import (
 type YAML struct {
 	gentype.GenericAdapter
 
-	visited map[string]struct{}
+	// freeform are the generated nodes of the maps and omitted structs, not merged key by key.
+	freeform map[*yaml.Node]bool
+	// aliases are the old keys from the `yamlAliases` tag of the generated key nodes.
+	aliases map[*yaml.Node][]string
 }
 
-func New(sourceStruct gentype.Source, outputOptions gentype.OutputOptions) *YAML {
+func New(model *gentype.Model, outputOptions gentype.OutputOptions) *YAML {
 	return &YAML{
-		GenericAdapter: gentype.GenericAdapter{
-			Source:        sourceStruct,
-			OutputOptions: outputOptions,
-		},
+		GenericAdapter: gentype.NewGenericAdapter(model, outputOptions),
 
-		visited: make(map[string]struct{}),
+		freeform: make(map[*yaml.Node]bool),
+		aliases:  make(map[*yaml.Node][]string),
+	}
+}
+
+// Options returns the options declared by the YAML adapter.
+func Options() []gentype.AdapterOption {
+	return []gentype.AdapterOption{
+		{
+			Name:    "tag",
+			Usage:   "Tag name for a YAML field names",
+			Default: gentype.TagYAML,
+			Apply: func(out *gentype.OutputOptions, value string) {
+				out.Tag = value
+			},
+		},
+		{
+			Name:    gentype.ParamMerge,
+			Usage:   "Merge the generated keys into the existing YAML file, keeping the user values and comments",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    gentype.ParamPrune,
+			Usage:   "Remove the keys missing in the struct from the merged YAML file instead of marking them deprecated",
+			Default: "false",
+			Bool:    true,
+		},
+		{
+			Name:    gentype.ParamOverlay,
+			Usage:   "Write only the keys with values differing from the base output, e.g. of the profile",
+			Default: "false",
+			Bool:    true,
+		},
 	}
 }
 
 func (g *YAML) Generate(ctx context.Context) (gentype.OutputFiles, error) {
-	yamlNode := g.structToYAMLNode(ctx, g.Source.Struct)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	overlay, err := g.OutputOptions.BoolParam(gentype.ParamOverlay)
+	if err != nil {
+		return nil, err
+	}
+
+	var yamlNode *yaml.Node
+
+	if overlay {
+		yamlNode, err = g.overlay()
+	} else {
+		yamlNode, err = g.structToYAMLNode(g.Model.Root)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	merge, err := g.OutputOptions.BoolParam(gentype.ParamMerge)
+	if err != nil {
+		return nil, err
+	}
+
+	if merge {
+		merged, err := g.mergeExisting(yamlNode)
+		if err != nil {
+			return nil, err
+		}
+
+		if merged != nil {
+			return gentype.OutputFiles{merged}, nil
+		}
+	}
 
 	data, err := yaml.Marshal(yamlNode)
 	if err != nil {
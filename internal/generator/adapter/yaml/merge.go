@@ -0,0 +1,211 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"gopkg.in/yaml.v3"
+)
+
+// deprecatedComment marks the keys of the merged file missing in the struct.
+const deprecatedComment = "# DEPRECATED: not in struct"
+
+// Indents of the encoded YAML, the range is limited by the encoder.
+const (
+	defaultIndent = 4
+	minIndent     = 2
+	maxIndent     = 9
+)
+
+// renamedComment marks the keys of the merged file set by the old name from the `yamlAliases` tag.
+const renamedComment = "# DEPRECATED: renamed to "
+
+// mergeExisting merges the generated mapping into the existing target file:
+// missing keys are added with the defaults and comments, user values, order and comments are kept,
+// keys missing in the struct are marked as deprecated or removed if prune is enabled.
+// Keys set by their aliases are marked as renamed and kept with the user values.
+// Returns nil if target file does not exist or is empty.
+func (g *YAML) mergeExisting(generated *yaml.Node) ([]byte, error) {
+	content, err := os.ReadFile(g.OutputOptions.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", g.OutputOptions.Path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", g.OutputOptions.Path, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("merge %s: root node is not a mapping", g.OutputOptions.Path)
+	}
+
+	prune, err := g.OutputOptions.BoolParam(gentype.ParamPrune)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mergeMappings(doc.Content[0], generated, prune)
+
+	buf := &bytes.Buffer{}
+
+	// The indent of the user file is kept.
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(detectIndent(content))
+
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("marshal YAML nodes: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal YAML nodes: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// detectIndent returns the smallest indent of the YAML content lines,
+// the default indent of the generated files if the content has no nested lines.
+func detectIndent(content []byte) int {
+	indent := 0
+
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || len(trimmed) == len(line) {
+			continue
+		}
+
+		if n := len(line) - len(trimmed); indent == 0 || n < indent {
+			indent = n
+		}
+	}
+
+	if indent < minIndent || indent > maxIndent {
+		return defaultIndent
+	}
+
+	return indent
+}
+
+//nolint:cyclop
+func (g *YAML) mergeMappings(dst *yaml.Node, src *yaml.Node, prune bool) {
+	if len(dst.Content) == 0 {
+		dst.Style = src.Style
+	}
+
+	// known are the keys and their aliases, mapped to the current keys.
+	known := make(map[string]string, len(src.Content)/2)
+	insertAt := 0
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		known[key.Value] = key.Value
+
+		for _, alias := range g.aliases[key] {
+			known[alias] = key.Value
+		}
+
+		pos := g.existingKeyIndex(dst, key)
+		if pos < 0 {
+			// New keys are inserted after the preceding sibling to keep the struct order.
+			dst.Content = slices.Insert(dst.Content, insertAt, key, value)
+			insertAt += 2
+
+			continue
+		}
+
+		unmarkDeprecated(dst.Content[pos])
+
+		existing := dst.Content[pos+1]
+		if existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode && !g.freeform[value] {
+			g.mergeMappings(existing, value, prune)
+		}
+
+		insertAt = max(insertAt, pos+2)
+	}
+
+	for i := 0; i+1 < len(dst.Content); {
+		key := dst.Content[i]
+		current, ok := known[key.Value]
+
+		switch {
+		case key.Tag == "!!merge" || current == key.Value:
+		case !ok && prune:
+			dst.Content = slices.Delete(dst.Content, i, i+2)
+
+			continue
+		case !ok:
+			markDeprecated(key, deprecatedComment)
+		default:
+			markDeprecated(key, renamedComment+current)
+		}
+
+		i += 2
+	}
+}
+
+// existingKeyIndex returns the index of the generated key in the existing mapping, -1 if not found.
+// The key set by its alias is found by the alias, so it is not added with the default value
+// overriding the alias.
+func (g *YAML) existingKeyIndex(mapping *yaml.Node, key *yaml.Node) int {
+	for _, name := range append([]string{key.Value}, g.aliases[key]...) {
+		if pos := mappingKeyIndex(mapping, name); pos >= 0 {
+			return pos
+		}
+	}
+
+	return -1
+}
+
+func mappingKeyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func markDeprecated(key *yaml.Node, comment string) {
+	if slices.Contains(strings.Split(key.HeadComment, "\n"), comment) {
+		return
+	}
+
+	// The previous mark is replaced, the key could be renamed or returned to the struct.
+	unmarkDeprecated(key)
+
+	if key.HeadComment == "" {
+		key.HeadComment = comment
+
+		return
+	}
+
+	key.HeadComment += "\n" + comment
+}
+
+func unmarkDeprecated(key *yaml.Node) {
+	if !strings.Contains(key.HeadComment, deprecatedComment) && !strings.Contains(key.HeadComment, renamedComment) {
+		return
+	}
+
+	lines := slices.DeleteFunc(strings.Split(key.HeadComment, "\n"), func(line string) bool {
+		return line == deprecatedComment || strings.HasPrefix(line, renamedComment)
+	})
+
+	key.HeadComment = strings.Join(lines, "\n")
+}
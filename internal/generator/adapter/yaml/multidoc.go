@@ -0,0 +1,66 @@
+package yaml
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a named document of the multi-document YAML stream.
+type Document struct {
+	// Name is a document name, e.g. the profile name.
+	Name string
+
+	// Options are the document output options, only the Tag and DefaultValueTag are used.
+	Options gentype.OutputOptions
+}
+
+// GenerateMultiDoc generates the YAML stream with one `---`-separated document per given document,
+// each headed with the `# Profile: <name>` comment.
+// If key is given, each document starts with the `<key>: <name>` discriminator.
+func GenerateMultiDoc(ctx context.Context, model *gentype.Model, docs []Document, key string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(gentype.GetDocComment("#", model.Source.RootStructName, model.Source.RootStructDoc))
+
+	enc := yaml.NewEncoder(buf)
+
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		node, err := New(model, doc.Options).structToYAMLNode(model.Root)
+		if err != nil {
+			return nil, fmt.Errorf("document %s: %w", doc.Name, err)
+		}
+
+		if key != "" {
+			if mappingKeyIndex(node, key) >= 0 {
+				return nil, fmt.Errorf("document %s: discriminator key %s is already used by the struct", doc.Name, key)
+			}
+
+			node.Content = append([]*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: doc.Name},
+			}, node.Content...)
+		}
+
+		err = enc.Encode(&yaml.Node{
+			Kind:        yaml.DocumentNode,
+			HeadComment: "Profile: " + doc.Name,
+			Content:     []*yaml.Node{node},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal YAML nodes: %w", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal YAML nodes: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
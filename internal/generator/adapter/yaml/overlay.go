@@ -0,0 +1,105 @@
+package yaml
+
+import (
+	"go/token"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"gopkg.in/yaml.v3"
+)
+
+// overlay returns the mapping of the fields with values differing from the base output,
+// generated with the tags from the base params, the default YAML tag and values if not set.
+// The fields are written with the base output keys to be layered over the base file.
+// Fields skipped in the overlay or in the base cannot be expressed and are omitted.
+func (g *YAML) overlay() (*yaml.Node, error) {
+	baseOpt := g.OutputOptions
+	baseOpt.Tag = g.OutputOptions.Params[gentype.ParamBaseTag]
+	baseOpt.DefaultValueTag = g.OutputOptions.Params[gentype.ParamBaseValueTag]
+
+	if baseOpt.Tag == "" {
+		baseOpt.Tag = gentype.TagYAML
+	}
+
+	return g.diffStruct(g.Model.Root, New(g.Model, baseOpt))
+}
+
+func (g *YAML) diffStruct(node *gentype.Node, base *YAML) (*yaml.Node, error) {
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, field := range node.Fields {
+		baseName := field.Key(base.OutputOptions.Tag, field.Name)
+		if baseName == "" || field.Key(g.OutputOptions.Tag, field.Name) == "" {
+			continue
+		}
+
+		if field.IsEmbedded && field.Kind == gentype.NodeKindStruct {
+			embedded, err := g.diffStruct(field, base)
+			if err != nil {
+				return nil, err
+			}
+
+			out.Content = append(out.Content, embedded.Content...)
+
+			continue
+		}
+
+		if !token.IsExported(field.Name) {
+			continue
+		}
+
+		value, err := g.diffField(field, base)
+		if err != nil {
+			return nil, err
+		}
+
+		if value != nil {
+			out.Content = append(out.Content, g.keyNode(field, baseName), value)
+		}
+	}
+
+	return out, nil
+}
+
+// diffField returns the value node of the field if it differs from the base one, nil otherwise.
+// Nested structs are reduced to their differing fields.
+func (g *YAML) diffField(field *gentype.Node, base *YAML) (*yaml.Node, error) {
+	if field.Kind == gentype.NodeKindStruct && field.RecursiveType() == nil {
+		value, err := g.diffStruct(field, base)
+		if err != nil || len(value.Content) == 0 {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	value, err := g.fieldValueNode(field)
+	if err != nil {
+		return nil, err
+	}
+
+	baseValue, err := base.fieldValueNode(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if equalNodes(value, baseValue) {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+// equalNodes compares the values of the nodes, ignoring the comments and styles.
+func equalNodes(a *yaml.Node, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Tag != b.Tag || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+
+	for i := range a.Content {
+		if !equalNodes(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+
+	return true
+}
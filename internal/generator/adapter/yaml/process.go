@@ -1,158 +1,151 @@
 package yaml
 
 import (
-	"context"
-	"go/types"
+	"fmt"
+	"go/token"
 	"strings"
 
 	"github.com/kukymbr/configen/internal/generator/gentype"
 	"gopkg.in/yaml.v3"
 )
 
-func (g *YAML) structToYAMLNode(ctx context.Context, st *types.Struct) *yaml.Node {
-	ctx = gentype.ContextIncRecursionDepth(ctx)
-	gentype.ContextMustValidateRecursionDepth(ctx, "YAML generator (structToYAMLNode)")
+func (g *YAML) structToYAMLNode(node *gentype.Node) (*yaml.Node, error) {
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
 
-	if ctx.Err() != nil {
-		return nil
-	}
-
-	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
-
-	for i := 0; i < st.NumFields(); i++ {
-		field := st.Field(i)
-		tag := st.Tag(i)
-
-		if content := g.processField(ctx, field, tag); content != nil {
-			node.Content = append(node.Content, content...)
+	for _, field := range node.Fields {
+		content, err := g.processField(field)
+		if err != nil {
+			return nil, err
 		}
+
+		out.Content = append(out.Content, content...)
 	}
 
-	return node
+	return out, nil
 }
 
-func (g *YAML) processField(
-	ctx context.Context,
-	field *types.Var,
-	tag string,
-) []*yaml.Node {
-	yamlName := gentype.ParseNameTag(tag, g.OutputOptions.Tag, field.Name())
+func (g *YAML) processField(field *gentype.Node) ([]*yaml.Node, error) {
+	yamlName := field.Key(g.OutputOptions.Tag, field.Name)
 	if yamlName == "" {
-		return nil
+		return nil, nil
 	}
 
-	value := gentype.ParseDefaultValue(tag, gentype.ValueTagsYAML(g.OutputOptions.DefaultValueTag)...)
-	comment := g.Source.CommentsMap[field.Pos()]
-	ft := field.Type()
+	if field.IsEmbedded && field.Kind == gentype.NodeKindStruct {
+		embedded, err := g.structToYAMLNode(field)
+		if err != nil {
+			return nil, err
+		}
 
-	if field.Anonymous() {
-		if stt, _, ok := gentype.GetUnderlyingStruct(ft); ok {
-			embedded := g.structToYAMLNode(ctx, stt)
-			if embedded == nil {
-				return nil
-			}
+		return embedded.Content, nil
+	}
 
-			return embedded.Content
-		}
+	if !token.IsExported(field.Name) {
+		return nil, nil
 	}
 
-	if !field.Exported() {
-		return nil
+	valNode, err := g.fieldValueNode(field)
+	if err != nil {
+		return nil, err
 	}
 
+	return []*yaml.Node{g.keyNode(field, yamlName), valNode}, nil
+}
+
+// keyNode returns the key node of the field with its comments.
+func (g *YAML) keyNode(field *gentype.Node, yamlName string) *yaml.Node {
+	comment := gentype.JoinComments(field.Comment, field.EnumComment(), field.DeprecatedComment())
 	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: yamlName}
-	valNode := g.typeToYAMLNode(ctx, ft, value)
+
+	if recursive := field.RecursiveType(); recursive != nil {
+		comment = gentype.JoinComments(comment, gentype.GetRecursionComment(recursive))
+	}
 
 	if comment != "" {
 		keyNode.HeadComment = comment
 	}
 
-	return []*yaml.Node{keyNode, valNode}
+	if aliases := field.YAMLAliases(); len(aliases) > 0 {
+		g.aliases[keyNode] = aliases
+	}
+
+	return keyNode
 }
 
-//nolint:cyclop,funlen
-func (g *YAML) typeToYAMLNode(ctx context.Context, t types.Type, value string) *yaml.Node {
-	if gentype.IsTextUnmarshaler(t) || gentype.IsStringer(t) {
-		return &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: value,
-			Tag:   "!!str",
-		}
+// fieldValueNode returns the value node of the field with its default value.
+func (g *YAML) fieldValueNode(field *gentype.Node) (*yaml.Node, error) {
+	if field.RecursiveType() != nil {
+		return g.freeformNode(getYAMLEmptyNode(field)), nil
 	}
 
-	switch tt := t.(type) {
-	case *types.Basic:
-		return getYAMLBasicNode(tt, value)
-	case *types.Pointer:
-		return g.typeToYAMLNode(ctx, tt.Elem(), value)
-	case *types.Slice, *types.Array:
-		elemType := tt.(interface{ Elem() types.Type }).Elem()
+	valNode, err := g.nodeToYAMLNode(field, field.Default(gentype.ValueTagsYAML(g.OutputOptions.DefaultValueTag)...))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.PathString(), err)
+	}
+
+	return valNode, nil
+}
 
+//nolint:cyclop
+func (g *YAML) nodeToYAMLNode(node *gentype.Node, value string) (*yaml.Node, error) {
+	switch node.Kind {
+	case gentype.NodeKindScalar:
+		return getYAMLScalarNode(node, value)
+	case gentype.NodeKindList:
 		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		values := []string{""}
 
-		if value != "" && !isStructLike(elemType) {
-			for _, v := range strings.Split(value, ",") {
-				elemNode := g.typeToYAMLNode(ctx, elemType, v)
-				seq.Content = append(seq.Content, elemNode)
-			}
-		} else {
-			seq.Content = append(seq.Content, g.typeToYAMLNode(ctx, elemType, ""))
+		if value != "" && !node.Elem.IsStructLike() {
+			values = strings.Split(value, ",")
 		}
 
-		return seq
-	case *types.Map:
-		if value != "" {
-			m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
-
-			for _, p := range strings.Split(value, ",") {
-				kv := strings.SplitN(p, "=", 2)
-				k := kv[0]
-				v := ""
-
-				if len(kv) == 2 {
-					v = kv[1]
-				}
-
-				m.Content = append(m.Content,
-					&yaml.Node{Kind: yaml.ScalarNode, Value: k},
-					&yaml.Node{Kind: yaml.ScalarNode, Value: v})
+		for _, v := range values {
+			elemNode, err := g.nodeToYAMLNode(node.Elem, v)
+			if err != nil {
+				return nil, err
 			}
 
-			return m
+			seq.Content = append(seq.Content, elemNode)
 		}
 
-		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
-	case *types.Named:
-		if st, ok := tt.Underlying().(*types.Struct); ok {
-			key := tt.String()
-			if _, ok := g.visited[key]; ok {
-				return &yaml.Node{
-					Kind:    yaml.MappingNode,
-					Tag:     "!!map",
-					Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "<recursive>"}},
-				}
-			}
-
-			g.visited[key] = struct{}{}
+		return seq, nil
+	case gentype.NodeKindMap:
+		m := g.freeformNode(&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
 
-			return g.structToYAMLNode(ctx, st)
+		if value == "" {
+			return m, nil
 		}
 
-		return g.typeToYAMLNode(ctx, tt.Underlying(), value)
-	case *types.Struct:
-		key := tt.String()
-		if _, ok := g.visited[key]; ok {
-			return &yaml.Node{
-				Kind:    yaml.MappingNode,
-				Tag:     "!!map",
-				Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "<recursive>"}},
+		for _, p := range strings.Split(value, ",") {
+			kv := strings.SplitN(p, "=", 2)
+			k := kv[0]
+			v := ""
+
+			if len(kv) == 2 {
+				v = kv[1]
 			}
+
+			m.Content = append(m.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: k},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: v})
 		}
 
-		g.visited[key] = struct{}{}
+		return m, nil
+	case gentype.NodeKindStruct:
+		if node.IsRecursive {
+			return g.freeformNode(getYAMLEmptyNode(node)), nil
+		}
 
-		return g.structToYAMLNode(ctx, tt)
+		return g.structToYAMLNode(node)
+	case gentype.NodeKindOpaque:
+		return g.freeformNode(getYAMLEmptyNode(node)), nil
 	}
 
-	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""}, nil
+}
+
+// freeformNode marks the node as not merged key by key.
+func (g *YAML) freeformNode(node *yaml.Node) *yaml.Node {
+	g.freeform[node] = true
+
+	return node
 }
@@ -7,6 +7,28 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+func getYAMLScalarNode(node *gentype.Node, value string) (*yaml.Node, error) {
+	if node.Scalar != nil {
+		val, err := node.Scalar.Value(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: node.Scalar.YAMLTag(), Value: val}, nil
+	}
+
+	t := node.Type
+	if pt, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pt.Elem()
+	}
+
+	if basic, ok := t.Underlying().(*types.Basic); ok {
+		return getYAMLBasicNode(basic, value), nil
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+}
+
 func getYAMLBasicNode(t *types.Basic, value string) *yaml.Node {
 	var tag string
 
@@ -27,11 +49,11 @@ func getYAMLBasicNode(t *types.Basic, value string) *yaml.Node {
 	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: val}
 }
 
-func isStructLike(t types.Type) bool {
-	switch t.(type) {
-	case *types.Struct, *types.Named:
-		return true
+// getYAMLEmptyNode returns an empty collection node for the omitted value.
+func getYAMLEmptyNode(node *gentype.Node) *yaml.Node {
+	if node.Kind == gentype.NodeKindList {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
 	}
 
-	return false
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: yaml.FlowStyle}
 }
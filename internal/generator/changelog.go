@@ -0,0 +1,22 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/kukymbr/configen/internal/generator/changelog"
+)
+
+// Keys returns the config keys of the source struct using the YAML and Env options tags,
+// see changelog.Compare to compare the keys of two struct versions.
+func (g *Generator) Keys(ctx context.Context) ([]changelog.Key, error) {
+	model, err := g.loadModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return changelog.Keys(model, changelog.Options{
+		YAMLTag:      g.opt.YAML.Tag,
+		EnvTag:       g.opt.Env.Tag,
+		EnvPrefixTag: g.opt.Env.PrefixTag,
+	}), nil
+}
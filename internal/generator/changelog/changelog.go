@@ -0,0 +1,131 @@
+// Package changelog compares the config keys of two source struct versions.
+package changelog
+
+import (
+	"go/types"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// Options of the config keys collecting.
+type Options struct {
+	// YAMLTag is a tag name for the YAML keys.
+	YAMLTag string
+
+	// EnvTag is a tag name for the dotenv variables names.
+	EnvTag string
+
+	// EnvPrefixTag is a tag name for the dotenv sub-structs variables prefixes.
+	EnvPrefixTag string
+
+	// DefaultValueTag is an explicit tag name for a default value, prepends the default lookup if given.
+	DefaultValueTag string
+}
+
+// Key is a config value of the struct.
+type Key struct {
+	// Path is a Go field names path from the root struct.
+	Path string `json:"path"`
+
+	// YAML is a YAML key path (e.g. `api.port`), empty if the field is skipped in YAML.
+	YAML string `json:"yaml,omitempty"`
+
+	// Env is a dotenv variable name, empty if the field has no variable.
+	Env string `json:"env,omitempty"`
+
+	// Type is a Go type of the field.
+	Type string `json:"type"`
+
+	// Default is a default value of the field.
+	Default string `json:"default,omitempty"`
+
+	// EnvDefault is a default value of the dotenv variable, empty if the field has no variable.
+	EnvDefault string `json:"envDefault,omitempty"`
+}
+
+// Name returns the YAML key path, or the dotenv variable name if the key is not in YAML.
+func (k Key) Name() string {
+	if k.YAML != "" {
+		return k.YAML
+	}
+
+	return k.Env
+}
+
+// Keys collects the config values of the model, in order of the struct fields.
+// Structs are expanded, the omitted recursive structs are the single values.
+func Keys(model *gentype.Model, opt Options) []Key {
+	c := &collector{opt: opt, qualifier: relativeTo(model.Source.Named)}
+
+	c.collect(model.Root, "", true, "")
+
+	return c.keys
+}
+
+type collector struct {
+	opt       Options
+	qualifier types.Qualifier
+	keys      []Key
+}
+
+func (c *collector) collect(node *gentype.Node, yamlPath string, inYAML bool, envPrefix string) {
+	for _, field := range node.Fields {
+		yamlKey := field.Key(c.opt.YAMLTag, field.Name)
+
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct && field.Named != nil {
+				c.collect(field, yamlPath, inYAML && yamlKey != "", envPrefix)
+			}
+
+			continue
+		}
+
+		if field.Kind == gentype.NodeKindStruct && !field.IsRecursive {
+			c.collect(field, joinKey(yamlPath, yamlKey, inYAML), inYAML && yamlKey != "",
+				envPrefix+field.TagValue(c.opt.EnvPrefixTag))
+
+			continue
+		}
+
+		key := Key{
+			Path:    field.PathString(),
+			YAML:    joinKey(yamlPath, yamlKey, inYAML),
+			Type:    types.TypeString(field.Type, c.qualifier),
+			Default: field.Default(gentype.ValueTagsYAML(c.opt.DefaultValueTag)...),
+		}
+
+		if name := field.Key(c.opt.EnvTag, ""); name != "" && !field.IsRecursive {
+			key.Env = envPrefix + name
+			key.EnvDefault = field.Default(gentype.ValueTagsEnv(c.opt.DefaultValueTag)...)
+		}
+
+		if key.YAML == "" && key.Env == "" {
+			continue
+		}
+
+		c.keys = append(c.keys, key)
+	}
+}
+
+func joinKey(path string, key string, inYAML bool) string {
+	if !inYAML || key == "" {
+		return ""
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// relativeTo returns the qualifier omitting the package of the root struct.
+func relativeTo(named *types.Named) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if named != nil && pkg == named.Obj().Pkg() {
+			return ""
+		}
+
+		return pkg.Name()
+	}
+}
@@ -0,0 +1,91 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	from := []Key{
+		{Path: "API.Host", YAML: "api.host", Env: "API_HOST", Type: "string", Default: "0.0.0.0"},
+		{Path: "API.Port", YAML: "api.port", Env: "API_PORT", Type: "int", Default: "8080"},
+		{Path: "API.Timeout", YAML: "api.timeout", Env: "API_TIMEOUT", Type: "int"},
+		{Path: "Debug", YAML: "debug", Type: "bool"},
+		{Path: "Legacy", Env: "LEGACY", Type: "string"},
+	}
+
+	to := []Key{
+		{Path: "API.Host", YAML: "api.hostname", Env: "API_HOSTNAME", Type: "string", Default: "0.0.0.0"},
+		{Path: "API.Port", YAML: "api.port", Env: "API_PORT", Type: "uint16", Default: "9090"},
+		{Path: "API.ReadTimeout", YAML: "api.timeout", Env: "API_TIMEOUT", Type: "time.Duration"},
+		{Path: "Debug", YAML: "debug", Type: "bool"},
+		{Path: "Workers", YAML: "workers", Env: "WORKERS", Type: "int", Default: "4"},
+	}
+
+	changes := Compare(from, to)
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeAdded, To: &to[4]},
+		{Kind: ChangeRemoved, From: &from[4]},
+		{Kind: ChangeRenamed, From: &from[0], To: &to[0]},
+		{Kind: ChangeType, From: &from[1], To: &to[1]},
+		{Kind: ChangeType, From: &from[2], To: &to[2]},
+		{Kind: ChangeDefault, From: &from[1], To: &to[1]},
+	}, changes)
+
+	assert.Empty(t, Compare(from, from))
+}
+
+func TestWrite(t *testing.T) {
+	changes := Compare(
+		[]Key{
+			{Path: "Host", YAML: "host", Env: "HOST", Type: "string"},
+			{Path: "Legacy", Env: "LEGACY", Type: "string"},
+		},
+		[]Key{
+			{Path: "Host", YAML: "host", Env: "HOST", Type: "string", Default: "localhost"},
+			{Path: "Port", YAML: "port", Env: "PORT", Type: "int", Default: "8080"},
+		},
+	)
+
+	tests := []struct {
+		Format   string
+		Expected string
+	}{
+		{
+			Format: FormatText,
+			Expected: "+ port (PORT): int = \"8080\"\n" +
+				"- LEGACY\n" +
+				"~ host (HOST): default \"\" -> \"localhost\"\n",
+		},
+		{
+			Format: FormatMarkdown,
+			Expected: "### Added\n\n- `port` (`PORT`): `int`, default `8080`\n\n" +
+				"### Removed\n\n- `LEGACY`\n\n" +
+				"### Changed defaults\n\n- `host` (`HOST`): _empty_ → `localhost`\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			require.NoError(t, Write(buf, changes, test.Format))
+			assert.Equal(t, test.Expected, buf.String())
+		})
+	}
+
+	t.Run(FormatJSON, func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		require.NoError(t, Write(buf, nil, FormatJSON))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Error(t, Write(&bytes.Buffer{}, changes, "html"))
+	})
+}
@@ -0,0 +1,107 @@
+package changelog
+
+import (
+	"slices"
+)
+
+// ChangeKind is a kind of the config key change.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a key added to the struct.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved is a key removed from the struct.
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeRenamed is a key with the changed YAML path or dotenv variable name.
+	ChangeRenamed ChangeKind = "renamed"
+
+	// ChangeType is a key with the changed Go type.
+	ChangeType ChangeKind = "type"
+
+	// ChangeDefault is a key with the changed default value.
+	ChangeDefault ChangeKind = "default"
+)
+
+// changeKinds are the change kinds in order of output.
+var changeKinds = []ChangeKind{ChangeAdded, ChangeRemoved, ChangeRenamed, ChangeType, ChangeDefault}
+
+// Change is a change of the config key between two struct versions.
+type Change struct {
+	// Kind is a kind of the change.
+	Kind ChangeKind `json:"kind"`
+
+	// From is a key in the old struct, nil for the added keys.
+	From *Key `json:"from,omitempty"`
+
+	// To is a key in the new struct, nil for the removed keys.
+	To *Key `json:"to,omitempty"`
+}
+
+// Compare returns the changes of the config keys,
+// ordered by the change kind and by the keys order in the struct.
+// Keys are matched by the Go field path first, then by the YAML path and the dotenv variable name,
+// so a renamed field keeping its key and a key renamed in the tag are both detected.
+func Compare(from []Key, to []Key) []Change {
+	matched := make(map[int]int, len(to))
+	matchedFrom := make(map[int]bool, len(from))
+
+	match := func(same func(a, b Key) bool) {
+		for i, newKey := range to {
+			if _, ok := matched[i]; ok {
+				continue
+			}
+
+			for j, oldKey := range from {
+				if !matchedFrom[j] && same(oldKey, newKey) {
+					matched[i] = j
+					matchedFrom[j] = true
+
+					break
+				}
+			}
+		}
+	}
+
+	match(func(a, b Key) bool { return a.Path == b.Path })
+	match(func(a, b Key) bool { return a.YAML != "" && a.YAML == b.YAML })
+	match(func(a, b Key) bool { return a.Env != "" && a.Env == b.Env })
+
+	var changes []Change
+
+	for i := range to {
+		j, ok := matched[i]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, To: &to[i]})
+
+			continue
+		}
+
+		oldKey, newKey := &from[j], &to[i]
+
+		if oldKey.YAML != newKey.YAML || oldKey.Env != newKey.Env {
+			changes = append(changes, Change{Kind: ChangeRenamed, From: oldKey, To: newKey})
+		}
+
+		if oldKey.Type != newKey.Type {
+			changes = append(changes, Change{Kind: ChangeType, From: oldKey, To: newKey})
+		}
+
+		if oldKey.Default != newKey.Default {
+			changes = append(changes, Change{Kind: ChangeDefault, From: oldKey, To: newKey})
+		}
+	}
+
+	for j := range from {
+		if !matchedFrom[j] {
+			changes = append(changes, Change{Kind: ChangeRemoved, From: &from[j]})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		return slices.Index(changeKinds, a.Kind) - slices.Index(changeKinds, b.Kind)
+	})
+
+	return changes
+}
@@ -0,0 +1,170 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Output formats of the changes.
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Formats are the supported output formats.
+var Formats = []string{FormatText, FormatMarkdown, FormatJSON}
+
+// markdownTitles are the Markdown sections titles of the change kinds.
+var markdownTitles = map[ChangeKind]string{
+	ChangeAdded:   "Added",
+	ChangeRemoved: "Removed",
+	ChangeRenamed: "Renamed",
+	ChangeType:    "Changed types",
+	ChangeDefault: "Changed defaults",
+}
+
+// Write writes the changes to w in the given format.
+func Write(w io.Writer, changes []Change, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, changes)
+	case FormatMarkdown:
+		return writeMarkdown(w, changes)
+	case FormatJSON:
+		return writeJSON(w, changes)
+	}
+
+	return fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(Formats, ", "))
+}
+
+func writeText(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No config changes.")
+
+		return err
+	}
+
+	for _, change := range changes {
+		var line string
+
+		switch change.Kind {
+		case ChangeAdded:
+			line = "+ " + keyLabel(*change.To, "%s (%s)") + ": " + change.To.Type + textDefault(change.To.Default)
+		case ChangeRemoved:
+			line = "- " + keyLabel(*change.From, "%s (%s)")
+		case ChangeRenamed:
+			line = "~ " + keyLabel(*change.From, "%s (%s)") + " renamed to " + keyLabel(*change.To, "%s (%s)")
+		case ChangeType:
+			line = "~ " + keyLabel(*change.To, "%s (%s)") + ": type " + change.From.Type + " -> " + change.To.Type
+		case ChangeDefault:
+			line = "~ " + keyLabel(*change.To, "%s (%s)") + ": default " +
+				strconv.Quote(change.From.Default) + " -> " + strconv.Quote(change.To.Default)
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeMarkdown(w io.Writer, changes []Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No config changes.")
+
+		return err
+	}
+
+	sb := &strings.Builder{}
+
+	for _, kind := range changeKinds {
+		idx := slices.IndexFunc(changes, func(c Change) bool { return c.Kind == kind })
+		if idx < 0 {
+			continue
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("### " + markdownTitles[kind] + "\n\n")
+
+		for _, change := range changes[idx:] {
+			if change.Kind != kind {
+				break
+			}
+
+			sb.WriteString("- ")
+
+			switch change.Kind {
+			case ChangeAdded:
+				sb.WriteString(keyLabel(*change.To, "`%s` (`%s`)") + ": `" + change.To.Type + "`")
+
+				if change.To.Default != "" {
+					sb.WriteString(", default `" + change.To.Default + "`")
+				}
+			case ChangeRemoved:
+				sb.WriteString(keyLabel(*change.From, "`%s` (`%s`)"))
+			case ChangeRenamed:
+				sb.WriteString(keyLabel(*change.From, "`%s` (`%s`)") + " → " + keyLabel(*change.To, "`%s` (`%s`)"))
+			case ChangeType:
+				sb.WriteString(keyLabel(*change.To, "`%s` (`%s`)") +
+					": `" + change.From.Type + "` → `" + change.To.Type + "`")
+			case ChangeDefault:
+				sb.WriteString(keyLabel(*change.To, "`%s` (`%s`)") +
+					": " + markdownValue(change.From.Default) + " → " + markdownValue(change.To.Default))
+			}
+
+			sb.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func writeJSON(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(changes)
+}
+
+// keyLabel formats the YAML path and the dotenv variable name of the key with the format,
+// a single name is formatted with the first verb only.
+func keyLabel(key Key, format string) string {
+	if key.YAML == "" || key.Env == "" {
+		single, _, _ := strings.Cut(format, " ")
+
+		return fmt.Sprintf(single, key.Name())
+	}
+
+	return fmt.Sprintf(format, key.YAML, key.Env)
+}
+
+func textDefault(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return " = " + strconv.Quote(value)
+}
+
+func markdownValue(value string) string {
+	if value == "" {
+		return "_empty_"
+	}
+
+	return "`" + value + "`"
+}
@@ -5,10 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"go/types"
+	"maps"
+	"slices"
 
-	"github.com/kukymbr/configen/internal/generator/adapter/env"
-	"github.com/kukymbr/configen/internal/generator/adapter/gogetter"
-	"github.com/kukymbr/configen/internal/generator/adapter/yaml"
+	"github.com/kukymbr/configen/internal/generator/adapter/usertpl"
 	"github.com/kukymbr/configen/internal/generator/gentype"
 	"github.com/kukymbr/configen/internal/logger"
 	"golang.org/x/sync/errgroup"
@@ -32,62 +32,108 @@ type Generator struct {
 	opt Options
 }
 
-//nolint:funlen
+// Result is a generated output of the adapter.
+type Result struct {
+	// Adapter is a name of the adapter.
+	Adapter string
+
+	// Profile is a name of the profile, empty for the main outputs.
+	Profile string
+
+	// Path is a target file path.
+	Path string
+
+	// Files are the generated files content, a single file at most.
+	Files gentype.OutputFiles
+}
+
+// Generate generates the enabled outputs and writes them to the target files.
 func (g *Generator) Generate(ctx context.Context) error {
-	logger.Debugf("Doing some magic...")
+	_, err := g.Write(ctx)
 
-	src, err := g.loadStruct()
+	return err
+}
+
+// Write generates the enabled outputs and writes them to the target files, skipping the unchanged ones.
+// Returns the target files with the write statuses in the GenerateFiles results order.
+func (g *Generator) Write(ctx context.Context) ([]WrittenFile, error) {
+	results, err := g.GenerateFiles(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	generators := []struct {
-		adapter func(out gentype.OutputOptions) gentype.Adapter
-		out     gentype.OutputOptions
-	}{
-		{
-			adapter: func(out gentype.OutputOptions) gentype.Adapter {
-				return yaml.New(src, out)
-			},
-			out: g.opt.YAML,
-		},
-		{
-			adapter: func(out gentype.OutputOptions) gentype.Adapter {
-				return env.New(src, out)
-			},
-			out: g.opt.Env,
-		},
-		{
-			adapter: func(out gentype.OutputOptions) gentype.Adapter {
-				return gogetter.New(src, out)
-			},
-			out: g.opt.GoGetter,
-		},
+	files := make([]WrittenFile, 0, len(results))
+
+	for _, res := range results {
+		for _, content := range res.Files {
+			status, err := writeFile(content, res.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, WrittenFile{Path: res.Path, Status: status})
+		}
 	}
 
-	errGroup, ctx := errgroup.WithContext(ctx)
+	logger.Successf("All done: %s.", SummarizeFiles(files))
 
-	for _, gen := range generators {
-		if !gen.out.Enable {
-			continue
+	return files, nil
+}
+
+// GenerateFiles generates the enabled outputs without writing them to the disk.
+// Results are ordered as the built-in outputs (YAML, dotenv, Go, Go fixture, flags),
+// followed by the Options.Outputs sorted by name, the Options.Templates,
+// the Options.Profiles outputs, the multi-document YAML and the profiles report.
+func (g *Generator) GenerateFiles(ctx context.Context) ([]Result, error) {
+	logger.Debugf("Doing some magic...")
+
+	model, err := g.loadModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := g.enabledOutputs()
+	results := make([]Result, len(outputs))
+
+	errGroup, groupCtx := errgroup.WithContext(ctx)
+
+	for i, out := range outputs {
+		if err := groupCtx.Err(); err != nil {
+			return nil, err
 		}
 
-		if err := ctx.Err(); err != nil {
-			return err
+		factory := out.factory
+		if factory == nil {
+			var ok bool
+
+			if factory, ok = g.opt.Registry.Lookup(out.adapter); !ok {
+				return nil, fmt.Errorf("adapter %s is not registered", out.adapter)
+			}
 		}
 
-		adapter := gen.adapter(gen.out)
+		adapter := factory(model, out.options)
 
 		errGroup.Go(func() error {
-			files, err := adapter.Generate(ctx)
-			if err != nil {
-				return err
+			files, err := adapter.Generate(groupCtx)
+
+			// Files are written to the output path, the files besides the first one would overwrite it.
+			if err == nil && len(files) > 1 {
+				err = fmt.Errorf("%d files generated for the single output path", len(files))
 			}
 
-			for _, content := range files {
-				if err := writeFile(content, gen.out.Path); err != nil {
-					return err
+			if err != nil {
+				if out.profile != "" {
+					return fmt.Errorf("profile %s: %s adapter: %w", out.profile, out.adapter, err)
 				}
+
+				return fmt.Errorf("%s adapter: %w", out.adapter, err)
+			}
+
+			results[i] = Result{
+				Adapter: out.adapter,
+				Profile: out.profile,
+				Path:    out.options.Path,
+				Files:   files,
 			}
 
 			return nil
@@ -95,45 +141,137 @@ func (g *Generator) Generate(ctx context.Context) error {
 	}
 
 	if err := errGroup.Wait(); err != nil {
-		return err
+		return nil, err
 	}
 
-	logger.Successf("All done.")
+	if g.opt.YAMLMultidoc != "" {
+		content, err := g.yamlMultidoc(ctx, model)
+		if err != nil {
+			return nil, fmt.Errorf("multi-document YAML: %w", err)
+		}
 
-	return nil
+		results = append(results, Result{
+			Adapter: AdapterYAMLMultidoc,
+			Path:    g.opt.YAMLMultidoc,
+			Files:   gentype.OutputFiles{content},
+		})
+	}
+
+	if g.opt.ProfileReport != "" {
+		results = append(results, Result{
+			Adapter: AdapterProfileReport,
+			Path:    g.opt.ProfileReport,
+			Files:   gentype.OutputFiles{g.profileReport(model)},
+		})
+	}
+
+	return results, nil
 }
 
-func (g *Generator) loadStruct() (gentype.Source, error) {
-	conf := &packages.Config{
-		Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedFiles,
-		Dir:  g.opt.SourceDir,
+type output struct {
+	adapter string
+	profile string
+	options gentype.OutputOptions
+	// factory overrides the registered adapter factory.
+	factory gentype.AdapterFactory
+}
+
+func (g *Generator) enabledOutputs() []output {
+	builtin := []output{
+		{adapter: AdapterYAML, options: g.opt.YAML},
+		{adapter: AdapterEnv, options: g.opt.Env},
+		{adapter: AdapterGoGetter, options: g.opt.GoGetter},
+		{adapter: AdapterGoFixture, options: g.opt.GoFixture},
+		{adapter: AdapterFlags, options: g.opt.Flags},
 	}
 
-	pkgs, err := packages.Load(conf, ".")
-	if err != nil {
-		return gentype.Source{}, fmt.Errorf("load package: %w", err)
+	outputs := make([]output, 0, len(builtin)+len(g.opt.Outputs)+len(g.opt.Templates)+len(g.opt.Profiles))
+
+	for _, out := range builtin {
+		if out.options.Enable {
+			outputs = append(outputs, out)
+		}
 	}
 
-	if len(pkgs) == 0 {
-		return gentype.Source{}, errors.New("no packages found")
+	for _, name := range slices.Sorted(maps.Keys(g.opt.Outputs)) {
+		outputs = append(outputs, output{adapter: name, options: g.opt.Outputs[name]})
+	}
+
+	for _, out := range g.opt.Templates {
+		outputs = append(outputs, output{
+			adapter: AdapterTemplate,
+			options: out,
+			factory: func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+				return usertpl.New(model, out)
+			},
+		})
+	}
+
+	for _, profile := range g.opt.Profiles {
+		for _, name := range slices.Sorted(maps.Keys(profile.Outputs)) {
+			outputs = append(outputs, output{adapter: name, profile: profile.Name, options: profile.Outputs[name]})
+		}
+	}
+
+	return outputs
+}
+
+func (g *Generator) loadModel(ctx context.Context) (*gentype.Model, error) {
+	src, err := g.loadStruct()
+	if err != nil {
+		return nil, err
 	}
 
-	pkg := pkgs[0]
+	return gentype.NewModel(gentype.ContextWithMaxRecursionDepth(ctx, g.opt.MaxDepth), src)
+}
+
+func (g *Generator) loadStruct() (gentype.Source, error) {
+	pkg, err := g.loadPackage(packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedFiles)
+	if err != nil {
+		return gentype.Source{}, err
+	}
 
 	obj := pkg.Types.Scope().Lookup(g.opt.StructName)
 	if obj == nil {
 		return gentype.Source{}, errors.New("struct not found: " + g.opt.StructName)
 	}
 
-	named, ok := obj.Type().(*types.Named)
+	named, ok := types.Unalias(obj.Type()).(*types.Named)
 	if !ok {
 		return gentype.Source{}, fmt.Errorf("%q is not a named type", g.opt.StructName)
 	}
 
+	if named.TypeParams().Len() > 0 && named.TypeArgs().Len() == 0 {
+		return gentype.Source{}, fmt.Errorf(
+			"%q is a generic type, declare an instantiated type or alias (e.g. `type cfg = %s[int]`)",
+			g.opt.StructName, g.opt.StructName,
+		)
+	}
+
 	structType, ok := named.Underlying().(*types.Struct)
 	if !ok {
 		return gentype.Source{}, fmt.Errorf("%q is not a struct", g.opt.StructName)
 	}
 
-	return gentype.NewSource(pkg, g.opt.StructName, named, structType), nil
+	scalars := gentype.NewScalarRegistry(g.opt.ScalarTypes...)
+
+	return gentype.NewSource(pkg, g.opt.StructName, named, structType, scalars), nil
+}
+
+func (g *Generator) loadPackage(mode packages.LoadMode) (*packages.Package, error) {
+	conf := &packages.Config{
+		Mode: mode,
+		Dir:  g.opt.SourceDir,
+	}
+
+	pkgs, err := packages.Load(conf, ".")
+	if err != nil {
+		return nil, fmt.Errorf("load package: %w", err)
+	}
+
+	if len(pkgs) == 0 {
+		return nil, errors.New("no packages found")
+	}
+
+	return pkgs[0], nil
 }
@@ -6,11 +6,14 @@ import (
 	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/adapter/gogetter"
+	"github.com/kukymbr/configen/internal/generator/changelog"
 	"github.com/kukymbr/configen/internal/generator/gentype"
 	"github.com/stretchr/testify/suite"
 )
@@ -60,6 +63,15 @@ func (s *GeneratorSuite) TestGenerator_PositiveCases() {
 					GoGetter: gentype.OutputOptions{
 						Enable: true,
 						Path:   s.getTargetPath(),
+						Params: map[string]string{gogetter.ParamMocks: "true", gogetter.ParamStore: "true", gogetter.ParamDiff: "true"},
+					},
+					GoFixture: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPathWithSuffix("_test.go"),
+					},
+					Flags: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
 					},
 				}
 			},
@@ -72,6 +84,30 @@ func (s *GeneratorSuite) TestGenerator_PositiveCases() {
 				s.assertContent(opt.YAML.Path, "config.yaml")
 				s.assertContent(opt.Env.Path, "config.env")
 				s.assertContent(opt.GoGetter.Path, "config.gen.go")
+				s.assertContent(opt.GoFixture.Path, "config_fixture_test.go")
+				s.assertContent(opt.Flags.Path, "config_flags.gen.go")
+			},
+		},
+		{
+			Name: "generate fixture to non-test file",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					GoFixture: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				content, err := os.ReadFile(opt.GoFixture.Path)
+				s.Require().NoError(err)
+				s.True(strings.HasPrefix(string(content), "// Package example contains configuration test fixtures.\n"))
 			},
 		},
 		{
@@ -98,8 +134,396 @@ func (s *GeneratorSuite) TestGenerator_PositiveCases() {
 			AssertFunc: func(opt generator.Options, err error) {
 				s.Require().NoError(err)
 
-				s.assertContent(opt.YAML.Path, "local.yaml")
-				s.assertContent(opt.Env.Path, "local.env")
+				s.assertContent(opt.YAML.Path, "local.yaml")
+				s.assertContent(opt.Env.Path, "local.env")
+			},
+		},
+		{
+			Name: "generate from dotless module",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					SourceDir:  "testdata/dotless",
+					YAML:       gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+					Env:        gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+					GoGetter:   gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+					Flags:      gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				// Structs of the module with a dotless path are not the standard library ones.
+				s.assertContent(opt.YAML.Path, "dotless.yaml")
+				s.assertContent(opt.Env.Path, "dotless.env")
+
+				content, err := os.ReadFile(opt.GoGetter.Path)
+				s.Require().NoError(err)
+				s.Contains(string(content), "func (c Config) API() APIConfig {")
+
+				// Enums of the module with a dotless path are detected.
+				content, err = os.ReadFile(opt.Flags.Path)
+				s.Require().NoError(err)
+				s.Contains(string(content), `"Mode is an application mode. Allowed values: debug, release"`)
+			},
+		},
+		{
+			Name: "generate generic instances",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					SourceDir:  "testdata/generics",
+					GoGetter:   gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				content, err := os.ReadFile(opt.GoGetter.Path)
+				s.Require().NoError(err)
+
+				// The type argument shapes are kept in the names of the generated structs.
+				s.Contains(string(content), "func (c Config) Count() OptionalInt {")
+				s.Contains(string(content), "func (c Config) Counts() OptionalSliceInt {")
+				s.Contains(string(content), "func (c Config) Limit() OptionalPtrInt {")
+				s.Contains(string(content), "func (c Config) Weights() OptionalMapStringToInt {")
+			},
+		},
+		{
+			Name: "generate profiles",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+					},
+					Profiles: []generator.Profile{{
+						Name:            "local",
+						YAMLTag:         "local",
+						DefaultValueTag: "localDefault",
+						Outputs: map[string]gentype.OutputOptions{
+							generator.AdapterYAML: {Path: s.getTargetPath()},
+							generator.AdapterEnv:  {Path: s.getTargetPath()},
+						},
+					}},
+					ProfileReport: s.getTargetPath(),
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				s.assertContent(opt.YAML.Path, "config.yaml")
+				s.assertContent(opt.Profiles[0].Outputs[generator.AdapterYAML].Path, "local.yaml")
+				s.assertContent(opt.Profiles[0].Outputs[generator.AdapterEnv].Path, "local.env")
+				s.assertContent(opt.ProfileReport, "profiles.md")
+			},
+		},
+		{
+			Name: "generate profiles report with different dotenv defaults",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					SourceDir:  "testdata/profiles",
+					Profiles: []generator.Profile{{
+						Name:            "local",
+						YAMLTag:         "local",
+						DefaultValueTag: "localDefault",
+						Outputs: map[string]gentype.OutputOptions{
+							generator.AdapterYAML: {Path: s.getTargetPath()},
+						},
+					}},
+					ProfileReport: s.getTargetPath(),
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.ProfileReport, "profiles_env_defaults.md")
+			},
+		},
+		{
+			Name: "generate overlay profile",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+					},
+					Profiles: []generator.Profile{{
+						Name:            "local",
+						YAMLTag:         "local",
+						DefaultValueTag: "localDefault",
+						Outputs: map[string]gentype.OutputOptions{
+							generator.AdapterYAML: {
+								Path:   s.getTargetPath(),
+								Params: map[string]string{gentype.ParamOverlay: "true"},
+							},
+						},
+					}},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				s.assertContent(opt.YAML.Path, "config.yaml")
+				s.assertContent(opt.Profiles[0].Outputs[generator.AdapterYAML].Path, "local.overlay.yaml")
+			},
+		},
+		{
+			Name: "generate multi-document yaml",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Profiles: []generator.Profile{{
+						Name:            "local",
+						YAMLTag:         "local",
+						DefaultValueTag: "localDefault",
+					}},
+					YAMLMultidoc:    s.getTargetPath(),
+					YAMLMultidocKey: "environment",
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.YAMLMultidoc, "multidoc.yaml")
+			},
+		},
+		{
+			Name: "merge yaml",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.copyToTarget("testdata/merge/existing.yaml"),
+						Params: map[string]string{gentype.ParamMerge: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.YAML.Path, "merged.yaml")
+
+				// Merge of the merged file changes nothing.
+				gen, err := generator.New(opt)
+				s.Require().NoError(err)
+				s.Require().NoError(gen.Generate(s.T().Context()))
+				s.assertContent(opt.YAML.Path, "merged.yaml")
+			},
+		},
+		{
+			Name: "merge yaml with prune",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.copyToTarget("testdata/merge/existing.yaml"),
+						Params: map[string]string{gentype.ParamMerge: "true", gentype.ParamPrune: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.YAML.Path, "merged_pruned.yaml")
+			},
+		},
+		{
+			Name: "merge env",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Env: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.copyToTarget("testdata/merge/existing.env"),
+						Params: map[string]string{gentype.ParamMerge: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.Env.Path, "merged.env")
+
+				// Merge of the merged file changes nothing.
+				gen, err := generator.New(opt)
+				s.Require().NoError(err)
+				s.Require().NoError(gen.Generate(s.T().Context()))
+				s.assertContent(opt.Env.Path, "merged.env")
+			},
+		},
+		{
+			Name: "merge env restores recursive struct note",
+			GetOptFunc: func() generator.Options {
+				path := s.copyToTarget("testdata/expected/config.env")
+
+				content, err := os.ReadFile(path)
+				s.Require().NoError(err)
+
+				note := "\n# UPSTREAM_FALLBACK_FALLBACK_*: Recursive type upstreamConfig, nested values are omitted."
+				s.Require().Contains(string(content), note)
+				s.Require().NoError(os.WriteFile(path, []byte(strings.Replace(string(content), note, "", 1)), 0o644))
+
+				return generator.Options{
+					StructName: givenStructName,
+					Env: gentype.OutputOptions{
+						Enable: true,
+						Path:   path,
+						Params: map[string]string{gentype.ParamMerge: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.Env.Path, "config.env")
+			},
+		},
+		{
+			Name: "merge env with prune",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Env: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.copyToTarget("testdata/merge/existing.env"),
+						Params: map[string]string{gentype.ParamMerge: "true", gentype.ParamPrune: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+				s.assertContent(opt.Env.Path, "merged_pruned.env")
+			},
+		},
+		{
+			Name: "generate template",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Templates: []gentype.OutputOptions{
+						{
+							TemplatePath: filepath.Join(givenSourceDir, "config.md.tpl"),
+							Path:         s.getTargetPath(),
+						},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				s.assertContent(opt.Templates[0].Path, "config.md")
+			},
+		},
+		{
+			Name: "generate go with custom template blocks",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					GoGetter: gentype.OutputOptions{
+						Enable:       true,
+						Path:         s.getTargetPath(),
+						TemplatePath: "testdata/templates/getters.go.tpl",
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				content, err := os.ReadFile(opt.GoGetter.Path)
+				s.Require().NoError(err)
+
+				s.Contains(string(content), "// GetHost returns the API.Host value (default 0.0.0.0).\n"+
+					"func (c *APIConfig) GetHost() string {")
+				s.Contains(string(content), "func NewAPIConfig(dto apiConfig) APIConfig {")
+				s.NotContains(string(content), "func (c APIConfig) Host() string {")
+			},
+		},
+		{
+			Name: "generate flags with stdlib flag package",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Flags: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+						Params: map[string]string{gogetter.ParamFlagsLib: gogetter.FlagsLibStd},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				content, err := os.ReadFile(opt.Flags.Path)
+				s.Require().NoError(err)
+
+				s.Contains(string(content), "func RegisterConfigFlags(fs *flag.FlagSet, dto *config) {")
+				s.Contains(string(content), `fs.IntVar(&dto.API.Port, "api.port", 8080, "")`)
+				s.Contains(string(content), `fs.Var(configFlagsURLVar(&dto.API.PublicURL, "http://localhost:8080"), "api.public_url", `)
+				s.NotContains(string(content), "pflag")
+			},
+		},
+		{
+			Name: "generate with plugin",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Outputs: map[string]gentype.OutputOptions{
+						"test": {Path: s.getTargetPath()},
+					},
+					Registry: s.getPluginsRegistry("test"),
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().NoError(err)
+
+				content, err := os.ReadFile(opt.Outputs["test"].Path)
+				s.Require().NoError(err)
+				s.Equal("app.instance_id: test\n", string(content))
 			},
 		},
 		{
@@ -170,6 +594,234 @@ func (s *GeneratorSuite) TestGenerator_NegativeCases() {
 				s.Require().Error(err)
 			},
 		},
+		{
+			Name: "reserved profile name",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Profiles: []generator.Profile{{
+						Name:    generator.DefaultProfileName,
+						Outputs: map[string]gentype.OutputOptions{generator.AdapterYAML: {Path: s.getTargetPath()}},
+					}},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().Error(err)
+			},
+		},
+		{
+			Name: "multi-document yaml key without path",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName:      givenStructName,
+					YAML:            gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+					YAMLMultidocKey: "environment",
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().Error(err)
+			},
+		},
+		{
+			Name: "multi-document yaml key used by struct",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName:      givenStructName,
+					YAMLMultidoc:    s.getTargetPath(),
+					YAMLMultidocKey: "app",
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().Error(err)
+			},
+		},
+		{
+			Name: "profile output without path",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Profiles: []generator.Profile{{
+						Name:    "local",
+						Outputs: map[string]gentype.OutputOptions{generator.AdapterYAML: {}},
+					}},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().Error(err)
+			},
+		},
+		{
+			Name: "max depth exceeded",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					MaxDepth:   2,
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				var depthErr *gentype.MaxDepthError
+
+				s.Require().ErrorAs(err, &depthErr)
+				s.Equal(2, depthErr.MaxDepth)
+				s.NoFileExists(opt.YAML.Path)
+			},
+		},
+		{
+			Name: "uninstantiated generic struct given",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: "optional",
+					YAML: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, "is a generic type")
+				s.NoFileExists(opt.YAML.Path)
+			},
+		},
+		{
+			Name: "failing plugin",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Outputs: map[string]gentype.OutputOptions{
+						"failing": {Path: s.getTargetPath()},
+					},
+					Registry: s.getPluginsRegistry("failing"),
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, "something went wrong")
+				s.NoFileExists(opt.Outputs["failing"].Path)
+			},
+		},
+		{
+			Name: "plugin with multiple files",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Outputs: map[string]gentype.OutputOptions{
+						"multiple": {Path: s.getTargetPath()},
+					},
+					Registry: s.getPluginsRegistry("multiple"),
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, "2 files generated for the single output path")
+				s.NoFileExists(opt.Outputs["multiple"].Path)
+			},
+		},
+		{
+			Name: "diff methods conflict",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					SourceDir:  "testdata/diff",
+					GoGetter: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+						Params: map[string]string{gogetter.ParamDiff: "true"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, "field Limits.Equal: getter conflicts with the Equal method")
+				s.NoFileExists(opt.GoGetter.Path)
+			},
+		},
+		{
+			Name: "generated struct names collision",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					SourceDir:  "testdata/collision",
+					GoGetter:   gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, "generated struct name OptionalInt is the same for optional[int] and optionalInt")
+				s.NoFileExists(opt.GoGetter.Path)
+			},
+		},
+		{
+			Name: "invalid flags library",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Flags: gentype.OutputOptions{
+						Enable: true,
+						Path:   s.getTargetPath(),
+						Params: map[string]string{gogetter.ParamFlagsLib: "kingpin"},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().NoError(err)
+			},
+			AssertFunc: func(opt generator.Options, err error) {
+				s.Require().ErrorContains(err, `invalid lib option value "kingpin"`)
+				s.NoFileExists(opt.Flags.Path)
+			},
+		},
+		{
+			Name: "unknown template",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Templates: []gentype.OutputOptions{
+						{
+							TemplatePath: "testdata/unknown.tpl",
+							Path:         s.getTargetPath(),
+						},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().Error(err)
+			},
+		},
+		{
+			Name: "unknown adapter",
+			GetOptFunc: func() generator.Options {
+				return generator.Options{
+					StructName: givenStructName,
+					Outputs: map[string]gentype.OutputOptions{
+						"unknown": {Path: s.getTargetPath()},
+					},
+				}
+			},
+			AssertConstructorFunc: func(err error) {
+				s.Require().ErrorContains(err, "unknown adapter unknown")
+			},
+		},
 		{
 			Name: "invalid struct format",
 			GetOptFunc: func() generator.Options {
@@ -273,6 +925,160 @@ func (s *GeneratorSuite) TestGenerator_NegativeCases() {
 	}
 }
 
+func (s *GeneratorSuite) TestGenerator_Validate() {
+	tests := []struct {
+		Name     string
+		Files    generator.ValidateFiles
+		Expected []string
+	}{
+		{
+			Name: "valid files",
+			Files: generator.ValidateFiles{
+				YAML: []string{filepath.Join(givenSourceDir, "config.yaml")},
+				Env:  []string{filepath.Join(givenSourceDir, "config.env")},
+			},
+		},
+		{
+			Name: "invalid yaml",
+			Files: generator.ValidateFiles{
+				YAML: []string{"testdata/validate/invalid.yaml"},
+			},
+			Expected: []string{
+				"testdata/validate/invalid.yaml:4: app.unknown_key: unknown key",
+				`testdata/validate/invalid.yaml:7: logger.format: value "xml" is not allowed, allowed values: text, json`,
+				"testdata/validate/invalid.yaml:9: api.host: missing required key",
+				`testdata/validate/invalid.yaml:9: api.port: expected integer (int), got "abc"`,
+				`testdata/validate/invalid.yaml:10: api.req_ttl: invalid time.Duration value "1hour": ` +
+					`time: unknown unit "hour" in duration "1hour"`,
+				`testdata/validate/invalid.yaml:11: api.trusted_nets: expected a sequence, got "10.0.0.0/8"`,
+				`testdata/validate/invalid.yaml:14: pool.workers.min: expected integer (int), got "1.5"`,
+			},
+		},
+		{
+			Name: "invalid env",
+			Files: generator.ValidateFiles{
+				Env: []string{"testdata/validate/invalid.env"},
+			},
+			Expected: []string{
+				"testdata/validate/invalid.env: API_HOST: missing required variable",
+				`testdata/validate/invalid.env:2: API_PORT: expected integer (int), got "80a"`,
+				"testdata/validate/invalid.env:4: UNKNOWN_VAR: unknown variable",
+				`testdata/validate/invalid.env:5: API_TRUSTED_NETS: invalid net/netip.Prefix value "invalid": ` +
+					`netip.ParsePrefix("invalid"): no '/'`,
+			},
+		},
+		{
+			Name: "aliases",
+			Files: generator.ValidateFiles{
+				YAML: []string{"testdata/validate/aliases.yaml"},
+				Env:  []string{"testdata/validate/aliases.env"},
+			},
+			Expected: []string{
+				"testdata/validate/aliases.yaml:3: api.response_ttl: deprecated alias of api.resp_ttl",
+				// The alias value is checked as the value of the field.
+				`testdata/validate/aliases.yaml:3: api.response_ttl: invalid time.Duration value "1hour": ` +
+					`time: unknown unit "hour" in duration "1hour"`,
+				"testdata/validate/aliases.env:2: API_RESPONSE_TTL: deprecated alias of API_RESP_TTL",
+				// The aliases are declared without the prefix.
+				"testdata/validate/aliases.env:3: RESPONSE_TTL: unknown variable",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			gen, err := generator.New(generator.Options{
+				StructName: givenStructName,
+				SourceDir:  givenSourceDir,
+			})
+			s.Require().NoError(err)
+
+			issues, err := gen.Validate(s.T().Context(), test.Files)
+			s.Require().NoError(err)
+
+			var actual []string
+			for _, issue := range issues {
+				actual = append(actual, issue.String())
+			}
+
+			s.Equal(test.Expected, actual)
+		})
+	}
+}
+
+func (s *GeneratorSuite) TestGenerator_Keys() {
+	gen, err := generator.New(generator.Options{
+		StructName: givenStructName,
+		SourceDir:  givenSourceDir,
+	})
+	s.Require().NoError(err)
+
+	keys, err := gen.Keys(s.T().Context())
+	s.Require().NoError(err)
+
+	s.Contains(keys, changelog.Key{
+		Path: "API.Port", YAML: "api.port", Env: "API_PORT", Type: "int", Default: "8080", EnvDefault: "8080",
+	})
+	s.Contains(keys, changelog.Key{
+		Path: "Pool.Workers.Max", YAML: "pool.workers.max", Env: "POOL_WORKERS_MAX", Type: "int",
+		Default: "4", EnvDefault: "4",
+	})
+	s.Contains(keys, changelog.Key{
+		Path: "Upstream.Fallback.Fallback", YAML: "upstream.fallback.fallback", Type: "*upstreamConfig",
+	})
+
+	// Keys of the same struct version have no changes.
+	s.Empty(changelog.Compare(keys, keys))
+}
+
+func (s *GeneratorSuite) TestGenerator_Write() {
+	opt := generator.Options{
+		StructName: givenStructName,
+		SourceDir:  givenSourceDir,
+		YAML:       gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+		Env:        gentype.OutputOptions{Enable: true, Path: s.getTargetPath()},
+	}
+
+	gen, err := generator.New(opt)
+	s.Require().NoError(err)
+
+	files, err := gen.Write(s.T().Context())
+	s.Require().NoError(err)
+	s.Equal([]generator.WrittenFile{
+		{Path: opt.YAML.Path, Status: generator.FileCreated},
+		{Path: opt.Env.Path, Status: generator.FileCreated},
+	}, files)
+
+	s.Require().NoError(os.WriteFile(opt.Env.Path, []byte("EDITED=1\n"), 0o600))
+	s.Require().NoError(os.Chmod(opt.Env.Path, 0o600))
+
+	yamlStat, err := os.Stat(opt.YAML.Path)
+	s.Require().NoError(err)
+
+	files, err = gen.Write(s.T().Context())
+	s.Require().NoError(err)
+	s.Equal([]generator.WrittenFile{
+		{Path: opt.YAML.Path, Status: generator.FileUnchanged},
+		{Path: opt.Env.Path, Status: generator.FileUpdated},
+	}, files)
+	s.Equal("1 updated, 1 unchanged", generator.SummarizeFiles(files))
+
+	// Unchanged file is not touched, updated one keeps the permissions.
+	stat, err := os.Stat(opt.YAML.Path)
+	s.Require().NoError(err)
+	s.Equal(yamlStat.ModTime(), stat.ModTime())
+
+	stat, err = os.Stat(opt.Env.Path)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), stat.Mode().Perm())
+	s.assertContent(opt.Env.Path, "config.env")
+
+	// No temporary files are left.
+	tmpFiles, err := filepath.Glob(filepath.Join(filepath.Dir(opt.Env.Path), "."+filepath.Base(opt.Env.Path)+".*"))
+	s.Require().NoError(err)
+	s.Empty(tmpFiles)
+}
+
 func (s *GeneratorSuite) runGeneratorGenerateTest(test generatorGenerateTestCase) {
 	s.T().Helper()
 
@@ -314,14 +1120,54 @@ func (s *GeneratorSuite) assertContent(filename string, expectedName string) {
 	s.Require().Equal(string(expected), string(actual))
 }
 
+func (s *GeneratorSuite) getPluginsRegistry(names ...string) *generator.Registry {
+	s.T().Helper()
+
+	if runtime.GOOS == "windows" {
+		s.T().Skip("plugin scripts are not supported on windows")
+	}
+
+	registry := generator.NewDefaultRegistry()
+
+	for _, name := range names {
+		executable, err := filepath.Abs(filepath.Join("testdata/plugins", "configen-gen-"+name))
+		s.Require().NoError(err)
+		s.Require().NoError(registry.RegisterPlugin(name, executable))
+	}
+
+	return registry
+}
+
+// copyToTarget copies the file to the new target path.
+func (s *GeneratorSuite) copyToTarget(src string) string {
+	s.T().Helper()
+
+	content, err := os.ReadFile(src)
+	s.Require().NoError(err)
+
+	path := s.getTargetPath()
+
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, content, 0o644))
+
+	return path
+}
+
 func (s *GeneratorSuite) getTargetPath() string {
 	s.T().Helper()
 
+	return s.getTargetPathWithSuffix(".tmp")
+}
+
+func (s *GeneratorSuite) getTargetPathWithSuffix(suffix string) string {
+	s.T().Helper()
+
 	name := fmt.Sprintf(
-		"%s_%d-%d.tmp",
+		"%s_%d-%d%s",
 		strings.ReplaceAll(s.T().Name(), "/", "."),
 		time.Now().UnixNano(),
 		rand.Uint(),
+		suffix,
 	)
 	path := filepath.Join("testdata/target", name)
 
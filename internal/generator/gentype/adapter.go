@@ -9,11 +9,58 @@ type Adapter interface {
 	Generate(ctx context.Context) (OutputFiles, error)
 }
 
+// AdapterFactory creates an adapter for the config model and the output options.
+type AdapterFactory func(model *Model, outputOptions OutputOptions) Adapter
+
+// AdapterOption is an option declared by the adapter,
+// exposed as a `--<adapter>-<option>` command line flag.
+type AdapterOption struct {
+	// Name is an option name, e.g. `tag`.
+	Name string
+
+	// Usage is a flag description.
+	Usage string
+
+	// Default is a default value of the flag.
+	Default string
+
+	// Bool marks the boolean option, the bare `--<adapter>-<option>` flag sets it to `true`.
+	Bool bool
+
+	// Apply sets the value to the output options.
+	// If nil, value is stored into the OutputOptions.Params by the option name.
+	Apply func(out *OutputOptions, value string)
+}
+
+// ApplyTo sets the option value to the output options.
+func (o AdapterOption) ApplyTo(out *OutputOptions, value string) {
+	if o.Apply != nil {
+		o.Apply(out, value)
+
+		return
+	}
+
+	if out.Params == nil {
+		out.Params = make(map[string]string)
+	}
+
+	out.Params[o.Name] = value
+}
+
 type GenericAdapter struct {
 	Source        Source
+	Model         *Model
 	OutputOptions OutputOptions
 }
 
+func NewGenericAdapter(model *Model, outputOptions OutputOptions) GenericAdapter {
+	return GenericAdapter{
+		Source:        model.Source,
+		Model:         model,
+		OutputOptions: outputOptions,
+	}
+}
+
 func (g *GenericAdapter) Generate(_ context.Context) error {
 	return errors.New("not implemented")
 }
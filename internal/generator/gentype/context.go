@@ -2,24 +2,42 @@ package gentype
 
 import (
 	"context"
+	"fmt"
+	"go/types"
 )
 
 type ctxKey string
 
 const (
-	ctxKeyRecursionDepth ctxKey = "recursion_depth"
+	ctxKeyRecursionDepth    ctxKey = "recursion_depth"
+	ctxKeyMaxRecursionDepth ctxKey = "max_recursion_depth"
+	ctxKeyTypesStack        ctxKey = "types_stack"
 
-	maxRecursionDepth = 50
+	DefaultMaxRecursionDepth = 50
+
+	// maxTypeRepeats is a number of times the recursive type
+	// is expanded inside itself in the generated examples.
+	maxTypeRepeats = 1
 )
 
-func ContextIncRecursionDepth(ctx context.Context) context.Context {
-	depth := 0
+// MaxDepthError is returned when the max recursion depth is reached.
+type MaxDepthError struct {
+	Issuer   string
+	MaxDepth int
+}
 
-	if v := ctx.Value(ctxKeyRecursionDepth); v != nil {
-		depth = v.(int)
-	}
+func (e *MaxDepthError) Error() string {
+	return fmt.Sprintf("%s: max recursion depth (%d) reached", e.Issuer, e.MaxDepth)
+}
+
+// typesStack is a stack of the named types being expanded.
+type typesStack struct {
+	named  *types.Named
+	parent *typesStack
+}
 
-	return context.WithValue(ctx, ctxKeyRecursionDepth, depth+1)
+func ContextIncRecursionDepth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyRecursionDepth, ContextGetRecursionDepth(ctx)+1)
 }
 
 func ContextGetRecursionDepth(ctx context.Context) int {
@@ -30,10 +48,86 @@ func ContextGetRecursionDepth(ctx context.Context) int {
 	return 0
 }
 
-func ContextMustValidateRecursionDepth(ctx context.Context, issuer string) {
-	depth := ContextGetRecursionDepth(ctx)
+// ContextWithMaxRecursionDepth sets the max recursion depth, non-positive value sets the default.
+func ContextWithMaxRecursionDepth(ctx context.Context, depth int) context.Context {
+	if depth <= 0 {
+		depth = DefaultMaxRecursionDepth
+	}
+
+	return context.WithValue(ctx, ctxKeyMaxRecursionDepth, depth)
+}
+
+func ContextGetMaxRecursionDepth(ctx context.Context) int {
+	if v := ctx.Value(ctxKeyMaxRecursionDepth); v != nil {
+		return v.(int)
+	}
+
+	return DefaultMaxRecursionDepth
+}
+
+// ContextValidateRecursionDepth returns a *MaxDepthError if the max recursion depth is reached.
+func ContextValidateRecursionDepth(ctx context.Context, issuer string) error {
+	maxDepth := ContextGetMaxRecursionDepth(ctx)
+
+	if ContextGetRecursionDepth(ctx) > maxDepth {
+		return &MaxDepthError{Issuer: issuer, MaxDepth: maxDepth}
+	}
+
+	return nil
+}
+
+// ContextEnterType pushes the named type to the stack of the types being expanded.
+func ContextEnterType(ctx context.Context, named *types.Named) context.Context {
+	parent, _ := ctx.Value(ctxKeyTypesStack).(*typesStack)
+
+	return context.WithValue(ctx, ctxKeyTypesStack, &typesStack{named: named, parent: parent})
+}
+
+// ContextIsRecursionLimitReached checks if the struct type (or type of slice, array, map or pointer elements)
+// is already expanded enough times up the stack and should not be expanded anymore.
+// Returns the recursive named type if limit is reached.
+func ContextIsRecursionLimitReached(ctx context.Context, t types.Type) (*types.Named, bool) {
+	named := getElemNamedStruct(t)
+	if named == nil {
+		return nil, false
+	}
+
+	stack, _ := ctx.Value(ctxKeyTypesStack).(*typesStack)
+	repeats := 0
+
+	for ; stack != nil; stack = stack.parent {
+		if types.Identical(stack.named, named) {
+			repeats++
+		}
+	}
+
+	return named, repeats > maxTypeRepeats
+}
+
+// GetRecursionComment returns a comment for the omitted recursive value.
+func GetRecursionComment(named *types.Named) string {
+	return "Recursive type " + named.Obj().Name() + ", nested values are omitted."
+}
+
+func getElemNamedStruct(t types.Type) *types.Named {
+	for {
+		switch tt := types.Unalias(t).(type) {
+		case *types.Pointer:
+			t = tt.Elem()
+		case *types.Slice:
+			t = tt.Elem()
+		case *types.Array:
+			t = tt.Elem()
+		case *types.Map:
+			t = tt.Elem()
+		case *types.Named:
+			if _, ok := tt.Underlying().(*types.Struct); ok {
+				return tt
+			}
 
-	if depth > maxRecursionDepth {
-		panic(issuer + ": max recursion depth reached")
+			return nil
+		default:
+			return nil
+		}
 	}
 }
@@ -0,0 +1,141 @@
+package gentype
+
+import (
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// GetEnumValues returns the allowed values of the enum-like type:
+// a named type with a basic underlying type and a set of constants
+// of this type declared in the same package.
+// Returns nil if type is not enum-like.
+func GetEnumValues(t types.Type) []string {
+	if pt, ok := t.(*types.Pointer); ok {
+		t = pt.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	// Standard library constants are units and flags (time.Second, os.ModeDir), not enums.
+	if IsStdPackage(named.Obj().Pkg().Path()) {
+		return nil
+	}
+
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return nil
+	}
+
+	// Marshalers have their own representation, consts values are meaningless for config files.
+	if IsMarshaler(named) {
+		return nil
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	consts := make([]*types.Const, 0)
+
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+
+		consts = append(consts, c)
+	}
+
+	if len(consts) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(consts, func(a, b *types.Const) int {
+		return cmp.Compare(a.Pos(), b.Pos())
+	})
+
+	values := make([]string, 0, len(consts))
+
+	for _, c := range consts {
+		value := constValueString(c.Val())
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// JoinComments joins non-empty comments with a new line.
+func JoinComments(comments ...string) string {
+	return strings.Join(appendSlicesFiltered(comments), "\n")
+}
+
+func constValueString(val constant.Value) string {
+	if val.Kind() == constant.String {
+		return constant.StringVal(val)
+	}
+
+	return val.ExactString()
+}
+
+// IsStdPackage checks if package path belongs to the standard library,
+// i.e. is listed by the `go list std`.
+// Paths with a dot in the first element are never standard, e.g. `github.com/user/repo`,
+// while the dotless module paths, e.g. `myapp/config`, are looked up in the list.
+func IsStdPackage(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	if strings.Contains(first, ".") {
+		return false
+	}
+
+	// The loading error is returned by the NewModel, nothing is standard without the list.
+	if LoadStdPackages() != nil {
+		return false
+	}
+
+	_, ok := stdPackages.paths[path]
+
+	return ok
+}
+
+var stdPackages struct {
+	once sync.Once
+	err  error
+
+	paths map[string]struct{}
+}
+
+// LoadStdPackages loads the standard library packages list checked by the IsStdPackage.
+// Packages are loaded once on the first call, the subsequent calls return the first call result.
+func LoadStdPackages() error {
+	stdPackages.once.Do(func() {
+		stdPackages.paths, stdPackages.err = loadStdPackages()
+	})
+
+	return stdPackages.err
+}
+
+func loadStdPackages() (map[string]struct{}, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName,
+	}
+
+	pkgs, err := packages.Load(conf, "std")
+	if err != nil {
+		return nil, fmt.Errorf("fetch standard packages list: %w", err)
+	}
+
+	paths := make(map[string]struct{}, len(pkgs))
+	for _, pkg := range pkgs {
+		paths[pkg.PkgPath] = struct{}{}
+	}
+
+	return paths, nil
+}
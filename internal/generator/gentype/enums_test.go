@@ -0,0 +1,40 @@
+package gentype
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStdPackage(t *testing.T) {
+	tests := []struct {
+		Path     string
+		Expected bool
+	}{
+		{Path: "time", Expected: true},
+		{Path: "net/netip", Expected: true},
+		{Path: "github.com/user/repo", Expected: false},
+		{Path: "myapp", Expected: false},
+		{Path: "myapp/internal/config", Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			assert.Equal(t, test.Expected, IsStdPackage(test.Path))
+		})
+	}
+}
+
+func TestGetEnumValues_DotlessModule(t *testing.T) {
+	pkg := types.NewPackage("myapp/config", "config")
+	mode := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Mode", nil), types.Typ[types.String], nil)
+
+	for _, value := range []string{"debug", "release"} {
+		pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, "Mode"+value, mode, constant.MakeString(value)))
+	}
+
+	assert.Equal(t, []string{"debug", "release"}, GetEnumValues(mode))
+}
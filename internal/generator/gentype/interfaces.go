@@ -3,33 +3,63 @@ package gentype
 import (
 	"fmt"
 	"go/types"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 )
 
 var interfaces struct {
+	once sync.Once
+	err  error
+
 	textUnmarshaler *types.Interface
+	textMarshaler   *types.Interface
+	jsonMarshaler   *types.Interface
+	flagValue       *types.Interface
 	stringer        *types.Interface
 }
 
-//nolint:gochecknoinits
-func init() {
-	if err := LoadInterfaces(); err != nil {
-		panic(err)
-	}
+// LoadInterfaces loads the standard library interfaces checked by the Is* functions.
+// Packages are loaded once on the first call, the subsequent calls return the first call result.
+func LoadInterfaces() error {
+	interfaces.once.Do(func() {
+		interfaces.err = loadInterfaces()
+	})
+
+	return interfaces.err
 }
 
-func LoadInterfaces() error {
-	var err error
+func loadInterfaces() error {
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+	}
 
-	interfaces.textUnmarshaler, err = lookupInterface("encoding", "TextUnmarshaler")
+	pkgs, err := packages.Load(conf, "encoding", "encoding/json", "flag", "fmt")
 	if err != nil {
-		return err
+		return fmt.Errorf("fetch standard packages info: %w", err)
 	}
 
-	interfaces.stringer, err = lookupInterface("fmt", "Stringer")
-	if err != nil {
-		return err
+	scopes := make(map[string]*types.Scope, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.Types != nil {
+			scopes[pkg.PkgPath] = pkg.Types.Scope()
+		}
+	}
+
+	for _, intf := range []struct {
+		target **types.Interface
+		pkg    string
+		name   string
+	}{
+		{&interfaces.textUnmarshaler, "encoding", "TextUnmarshaler"},
+		{&interfaces.textMarshaler, "encoding", "TextMarshaler"},
+		{&interfaces.jsonMarshaler, "encoding/json", "Marshaler"},
+		{&interfaces.flagValue, "flag", "Value"},
+		{&interfaces.stringer, "fmt", "Stringer"},
+	} {
+		if *intf.target, err = lookupInterface(scopes[intf.pkg], intf.pkg, intf.name); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -43,25 +73,58 @@ func IsStringer(t types.Type) bool {
 	return implements(t, interfaces.stringer)
 }
 
-func lookupInterface(pkg string, name string) (*types.Interface, error) {
-	conf := &packages.Config{
-		Mode: packages.NeedTypes | packages.NeedTypesInfo,
+// IsMarshaler checks if type controls its own text representation:
+// implements encoding.TextMarshaler, encoding.TextUnmarshaler, json.Marshaler,
+// yaml.Marshaler, yaml.Unmarshaler or flag.Value.
+func IsMarshaler(t types.Type) bool {
+	// The interfaces are listed below, so they must be loaded before.
+	_ = LoadInterfaces()
+
+	for _, intf := range []*types.Interface{
+		interfaces.textMarshaler,
+		interfaces.textUnmarshaler,
+		interfaces.jsonMarshaler,
+		interfaces.flagValue,
+	} {
+		if implements(t, intf) {
+			return true
+		}
 	}
 
-	pkgs, err := packages.Load(conf, pkg)
-	if err != nil || len(pkgs) == 0 {
-		return nil, fmt.Errorf("fetch package info for %s: %w", pkg, err)
+	// The yaml package is not always available to load, so its interfaces are checked by method names.
+	return hasMethod(t, "MarshalYAML") || hasMethod(t, "UnmarshalYAML")
+}
+
+func lookupInterface(scope *types.Scope, pkg string, name string) (*types.Interface, error) {
+	if scope == nil {
+		return nil, fmt.Errorf("fetch package info for %s: package not loaded", pkg)
 	}
 
-	scope := pkgs[0].Types.Scope()
 	if tm := scope.Lookup(name); tm != nil {
-		return tm.Type().Underlying().(*types.Interface), nil
+		if intf, ok := tm.Type().Underlying().(*types.Interface); ok {
+			return intf, nil
+		}
 	}
 
 	return nil, fmt.Errorf("failed to find interface %s in %s", name, pkg)
 }
 
+func hasMethod(t types.Type, name string) bool {
+	for _, typ := range []types.Type{t, types.NewPointer(t)} {
+		if sel := types.NewMethodSet(typ).Lookup(nil, name); sel != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func implements(t types.Type, intf *types.Interface) bool {
+	// The loading error is returned by the NewModel, nothing is implemented without the interfaces.
+	if LoadInterfaces() != nil {
+		return false
+	}
+
 	if types.Implements(t, intf) {
 		return true
 	}
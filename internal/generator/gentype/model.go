@@ -0,0 +1,265 @@
+package gentype
+
+import (
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NodeKind is a kind of the config model node.
+type NodeKind int
+
+const (
+	// NodeKindScalar is a single value: basic type, registered scalar or an unsupported type.
+	NodeKindScalar NodeKind = iota
+
+	// NodeKindStruct is a struct with the fields.
+	NodeKindStruct
+
+	// NodeKindList is a slice or an array.
+	NodeKindList
+
+	// NodeKindMap is a map.
+	NodeKindMap
+
+	// NodeKindOpaque is a standard library struct, which is not expanded.
+	NodeKindOpaque
+)
+
+func (k NodeKind) String() string {
+	switch k {
+	case NodeKindScalar:
+		return "scalar"
+	case NodeKindStruct:
+		return "struct"
+	case NodeKindList:
+		return "list"
+	case NodeKindMap:
+		return "map"
+	case NodeKindOpaque:
+		return "opaque"
+	}
+
+	return "unknown"
+}
+
+// Model is a config struct representation shared by the adapters.
+type Model struct {
+	Source Source
+	Root   *Node
+}
+
+// Node is a field of the config model.
+// The root struct and the list or map elements are nodes too.
+type Node struct {
+	// Name is a Go field name, empty for the root and elements.
+	Name string
+
+	// Path is a Go field names path from the root struct.
+	// Embedded structs are not the part of the path.
+	Path []string
+
+	// Index is an index of the field in the parent struct.
+	Index int
+
+	// Type is a Go type of the field.
+	Type types.Type
+
+	// Kind is a kind of the node.
+	Kind NodeKind
+
+	// Named is a named type of the struct and opaque nodes, nil for the anonymous structs.
+	Named *types.Named
+
+	// Tag is a raw struct tag of the field.
+	Tag string
+
+	// Comment is a field doc comment.
+	Comment string
+
+	// Enum are the allowed values of the enum-like types.
+	Enum []string
+
+	// Scalar is a definition of the registered scalar type.
+	Scalar *ScalarType
+
+	// Fields are the struct fields, including the embedded structs.
+	Fields []*Node
+
+	// Elem is a list or map element.
+	Elem *Node
+
+	// IsEmbedded is set for the embedded structs.
+	IsEmbedded bool
+
+	// IsPointer is set if field type is a pointer.
+	IsPointer bool
+
+	// IsRecursive is set if the nested values are omitted because of the recursion limit.
+	IsRecursive bool
+}
+
+// Key returns the name of the field in the tag, empty string if field is skipped (`-`).
+func (n *Node) Key(tag string, fallback string) string {
+	return ParseNameTag(n.Tag, tag, fallback)
+}
+
+// TagValue returns a raw value of the struct tag.
+func (n *Node) TagValue(tag string) string {
+	return reflect.StructTag(n.Tag).Get(tag)
+}
+
+// Default returns the first non-empty value of the given tags.
+func (n *Node) Default(tags ...string) string {
+	return ParseDefaultValue(n.Tag, tags...)
+}
+
+// IsSecret checks if field is marked as a secret with the `secret:"true"` tag.
+func (n *Node) IsSecret() bool {
+	isSecret, _ := strconv.ParseBool(n.TagValue(TagSecret))
+
+	return isSecret
+}
+
+// ContainsSecret checks if field is a secret or its value contains the secret fields,
+// e.g. a list of the structs with a secret field.
+// Types are checked instead of the nodes to include the omitted recursive structs.
+func (n *Node) ContainsSecret() bool {
+	return n.IsSecret() || typeContainsSecret(n.Type, make(map[types.Type]bool))
+}
+
+// IsRequired checks if field is marked as required
+// with the `required:"true"` tag or the `required` rule of the `validate` tag.
+func (n *Node) IsRequired() bool {
+	if isRequired, _ := strconv.ParseBool(n.TagValue(TagRequired)); isRequired {
+		return true
+	}
+
+	return HasTagOption(n.TagValue(TagValidate), TagRequired)
+}
+
+// Deprecation returns the deprecation message of the `deprecated:"use api.listen instead"` tag,
+// empty string if field is not deprecated.
+func (n *Node) Deprecation() string {
+	return strings.TrimSpace(n.TagValue(TagDeprecated))
+}
+
+// DeprecatedComment returns a comment line with the deprecation message.
+func (n *Node) DeprecatedComment() string {
+	if n.Deprecation() == "" {
+		return ""
+	}
+
+	return "Deprecated: " + n.Deprecation()
+}
+
+// EnvAliases returns the old dotenv variables names of the field without the prefix
+// from the `envAliases:"HOST,LISTEN_ADDR"` tag.
+func (n *Node) EnvAliases() []string {
+	return n.tagList(TagEnvAliases)
+}
+
+// YAMLAliases returns the old YAML keys of the field from the `yamlAliases:"host"` tag.
+func (n *Node) YAMLAliases() []string {
+	return n.tagList(TagYAMLAliases)
+}
+
+// tagList returns the non-empty comma-separated values of the tag.
+func (n *Node) tagList(tag string) []string {
+	var values []string
+
+	for _, value := range strings.Split(n.TagValue(tag), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// Value returns the value to render for the scalar node:
+// the given raw value validated for the registered scalars or the zero value of the type.
+func (n *Node) Value(raw string) (string, error) {
+	if n.Scalar != nil {
+		return n.Scalar.Value(raw)
+	}
+
+	return DefaultValueForType(n.Type, raw), nil
+}
+
+// EnumComment returns a comment line listing the enum values.
+func (n *Node) EnumComment() string {
+	if len(n.Enum) == 0 {
+		return ""
+	}
+
+	return "Allowed values: " + strings.Join(n.Enum, ", ")
+}
+
+// RecursiveType returns the omitted recursive type of the node or its elements,
+// nil if node is not omitted.
+func (n *Node) RecursiveType() *types.Named {
+	for node := n; node != nil; node = node.Elem {
+		if node.IsRecursive {
+			return node.Named
+		}
+	}
+
+	return nil
+}
+
+// IsStructLike checks if node is a struct or a pointer to the struct, expanded or not.
+func (n *Node) IsStructLike() bool {
+	return n.Kind == NodeKindStruct || n.Kind == NodeKindOpaque
+}
+
+// PathString returns the node path joined with the dot.
+func (n *Node) PathString() string {
+	return strings.Join(n.Path, ".")
+}
+
+// Walk calls fn for the node and all nested nodes (fields and elements) recursively, depth-first.
+// Nested nodes are not visited if fn returns false.
+func (n *Node) Walk(fn func(node *Node) bool) {
+	if !fn(n) {
+		return
+	}
+
+	for _, field := range n.Fields {
+		field.Walk(fn)
+	}
+
+	if n.Elem != nil {
+		n.Elem.Walk(fn)
+	}
+}
+
+func typeContainsSecret(t types.Type, visited map[types.Type]bool) bool {
+	switch tt := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return typeContainsSecret(tt.Elem(), visited)
+	case *types.Slice:
+		return typeContainsSecret(tt.Elem(), visited)
+	case *types.Array:
+		return typeContainsSecret(tt.Elem(), visited)
+	case *types.Map:
+		return typeContainsSecret(tt.Elem(), visited)
+	case *types.Named:
+		if visited[tt] {
+			return false
+		}
+
+		visited[tt] = true
+
+		return typeContainsSecret(tt.Underlying(), visited)
+	case *types.Struct:
+		for i := range tt.NumFields() {
+			if (&Node{Tag: tt.Tag(i)}).IsSecret() || typeContainsSecret(tt.Field(i).Type(), visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package gentype
+
+import (
+	"context"
+	"go/types"
+	"slices"
+)
+
+// NewModel builds the config model from the source struct.
+func NewModel(ctx context.Context, src Source) (*Model, error) {
+	if err := LoadInterfaces(); err != nil {
+		return nil, err
+	}
+
+	if err := LoadStdPackages(); err != nil {
+		return nil, err
+	}
+
+	b := &modelBuilder{src: src}
+
+	root := &Node{
+		Type:    src.Named,
+		Kind:    NodeKindStruct,
+		Named:   src.Named,
+		Comment: src.RootStructDoc,
+	}
+
+	if err := b.buildStruct(ContextEnterType(ctx, src.Named), root, src.Struct); err != nil {
+		return nil, err
+	}
+
+	return &Model{
+		Source: src,
+		Root:   root,
+	}, nil
+}
+
+type modelBuilder struct {
+	src Source
+}
+
+func (b *modelBuilder) buildStruct(ctx context.Context, node *Node, st *types.Struct) error {
+	ctx = ContextIncRecursionDepth(ctx)
+	if err := ContextValidateRecursionDepth(ctx, "Model builder (buildStruct)"); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	node.Kind = NodeKindStruct
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+
+		// Unexported fields are not accessible by the config loaders, except the embedded structs.
+		if !field.Exported() && !field.Anonymous() {
+			continue
+		}
+
+		child := &Node{
+			Name:       field.Name(),
+			Path:       node.Path,
+			Index:      i,
+			Type:       field.Type(),
+			Tag:        st.Tag(i),
+			Comment:    b.src.CommentsMap[field.Pos()],
+			Enum:       GetEnumValues(field.Type()),
+			IsEmbedded: field.Anonymous(),
+		}
+
+		if !child.IsEmbedded {
+			child.Path = append(slices.Clone(node.Path), field.Name())
+		}
+
+		if err := b.expand(ctx, child, field.Type()); err != nil {
+			return err
+		}
+
+		node.Fields = append(node.Fields, child)
+	}
+
+	return nil
+}
+
+//nolint:cyclop
+func (b *modelBuilder) expand(ctx context.Context, node *Node, t types.Type) error {
+	if scalar, ok := b.src.Scalars.Lookup(t); ok {
+		node.Kind = NodeKindScalar
+		node.Scalar = &scalar
+		_, node.IsPointer = types.Unalias(t).(*types.Pointer)
+
+		return nil
+	}
+
+	switch tt := types.Unalias(t).(type) {
+	case *types.Pointer:
+		node.IsPointer = true
+
+		return b.expand(ctx, node, tt.Elem())
+	case *types.Named:
+		st, ok := tt.Underlying().(*types.Struct)
+		if !ok {
+			return b.expand(ctx, node, tt.Underlying())
+		}
+
+		node.Named = tt
+
+		if tt.Obj().Pkg() != nil && IsStdPackage(tt.Obj().Pkg().Path()) {
+			node.Kind = NodeKindOpaque
+
+			return nil
+		}
+
+		if _, isLimitReached := ContextIsRecursionLimitReached(ctx, tt); isLimitReached {
+			node.Kind = NodeKindStruct
+			node.IsRecursive = true
+
+			return nil
+		}
+
+		return b.buildStruct(ContextEnterType(ctx, tt), node, st)
+	case *types.Struct:
+		return b.buildStruct(ctx, node, tt)
+	case *types.Slice:
+		node.Kind = NodeKindList
+
+		return b.expandElem(ctx, node, tt.Elem())
+	case *types.Array:
+		node.Kind = NodeKindList
+
+		return b.expandElem(ctx, node, tt.Elem())
+	case *types.Map:
+		node.Kind = NodeKindMap
+
+		return b.expandElem(ctx, node, tt.Elem())
+	}
+
+	node.Kind = NodeKindScalar
+
+	return nil
+}
+
+func (b *modelBuilder) expandElem(ctx context.Context, node *Node, elemType types.Type) error {
+	node.Elem = &Node{
+		Path: node.Path,
+		Type: elemType,
+		Enum: GetEnumValues(elemType),
+	}
+
+	return b.expand(ctx, node.Elem, elemType)
+}
@@ -0,0 +1,51 @@
+package gentype
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_Aliases(t *testing.T) {
+	node := &Node{Tag: `yaml:"listen" env:"LISTEN" envAliases:"HOST, LEGACY_HOST_2,,host" yamlAliases:"host,OldHost"`}
+
+	assert.Equal(t, []string{"HOST", "LEGACY_HOST_2", "host"}, node.EnvAliases())
+	assert.Equal(t, []string{"host", "OldHost"}, node.YAMLAliases())
+
+	assert.Empty(t, (&Node{Tag: `yaml:"listen"`}).EnvAliases())
+	assert.Empty(t, (&Node{Tag: `yaml:"listen"`}).YAMLAliases())
+}
+
+func TestNode_DeprecatedComment(t *testing.T) {
+	node := &Node{Tag: `yaml:"host" deprecated:"use api.listen instead"`}
+
+	assert.Equal(t, "use api.listen instead", node.Deprecation())
+	assert.Equal(t, "Deprecated: use api.listen instead", node.DeprecatedComment())
+	assert.Empty(t, (&Node{Tag: `yaml:"host"`}).DeprecatedComment())
+}
+
+func TestNode_ContainsSecret(t *testing.T) {
+	newStruct := func(name string, tag string) *types.Named {
+		named := types.NewNamed(types.NewTypeName(token.NoPos, nil, name, nil), nil, nil)
+		named.SetUnderlying(types.NewStruct(
+			[]*types.Var{
+				types.NewField(token.NoPos, nil, "Value", types.Typ[types.String], false),
+				types.NewField(token.NoPos, nil, "Next", types.NewPointer(named), false),
+			},
+			[]string{tag, ""},
+		))
+
+		return named
+	}
+
+	secret := newStruct("secretConfig", `secret:"true"`)
+	plain := newStruct("plainConfig", `yaml:"value"`)
+
+	assert.True(t, (&Node{Type: types.Typ[types.String], Tag: `secret:"true"`}).ContainsSecret())
+	assert.True(t, (&Node{Type: types.NewSlice(secret)}).ContainsSecret())
+	assert.True(t, (&Node{Type: types.NewMap(types.Typ[types.String], types.NewPointer(secret))}).ContainsSecret())
+	assert.False(t, (&Node{Type: types.NewSlice(plain)}).ContainsSecret())
+	assert.False(t, (&Node{Type: types.Typ[types.String]}).ContainsSecret())
+}
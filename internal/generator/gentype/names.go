@@ -65,6 +65,16 @@ func ToLowerCamel(name string) string {
 	return wordsToLowerCamel(nameToWords(name))
 }
 
+// ToSnake converts the name to the snake_case.
+func ToSnake(name string) string {
+	return strings.Join(nameToWords(name), "_")
+}
+
+// ToKebab converts the name to the kebab-case.
+func ToKebab(name string) string {
+	return strings.Join(nameToWords(name), "-")
+}
+
 //nolint:cyclop
 func nameToWords(s string) []string {
 	// Normalize separators to space
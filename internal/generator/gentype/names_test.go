@@ -60,3 +60,23 @@ func TestNameToParts(t *testing.T) {
 		})
 	}
 }
+
+func TestToSnakeAndKebab(t *testing.T) {
+	tests := []struct {
+		Input         string
+		ExpectedSnake string
+		ExpectedKebab string
+	}{
+		{Input: "APIConfig", ExpectedSnake: "api_config", ExpectedKebab: "api-config"},
+		{Input: "instanceID", ExpectedSnake: "instance_id", ExpectedKebab: "instance-id"},
+		{Input: "Req TTL", ExpectedSnake: "req_ttl", ExpectedKebab: "req-ttl"},
+		{Input: "name", ExpectedSnake: "name", ExpectedKebab: "name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedSnake, ToSnake(test.Input))
+			assert.Equal(t, test.ExpectedKebab, ToKebab(test.Input))
+		})
+	}
+}
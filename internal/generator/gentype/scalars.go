@@ -0,0 +1,299 @@
+package gentype
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scalar value types, as in JSON schema.
+const (
+	ScalarTypeString  = "string"
+	ScalarTypeInteger = "integer"
+	ScalarTypeNumber  = "number"
+	ScalarTypeBoolean = "boolean"
+)
+
+// ScalarType is a type rendered as a single value in the config files.
+type ScalarType struct {
+	// Name is a full type name, e.g. `time.Duration` or `net/url.URL`.
+	Name string
+
+	// Type is a value type, one of the ScalarType* constants.
+	Type string
+
+	// Sample is a value to render if no default value is defined.
+	Sample string
+
+	// Format is a format hint for the schema outputs, e.g. `duration` or `uri`.
+	Format string
+
+	// Parse validates the value; nil if no validation is available.
+	Parse func(value string) error
+}
+
+// ParseScalarType parses the scalar type definition
+// in the `<[pkg/path.]Type>:<type>[:<sample>]` format,
+// e.g. `github.com/shopspring/decimal.Decimal:number:0.00`.
+func ParseScalarType(definition string) (ScalarType, error) {
+	parts := strings.SplitN(definition, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || !strings.Contains(parts[0], ".") {
+		return ScalarType{}, fmt.Errorf(
+			"invalid scalar type definition %q, expected `<[pkg/path.]Type>:<type>[:<sample>]`", definition,
+		)
+	}
+
+	scalar := ScalarType{
+		Name: parts[0],
+		Type: parts[1],
+	}
+
+	if len(parts) == 3 {
+		scalar.Sample = parts[2]
+	}
+
+	switch scalar.Type {
+	case ScalarTypeString:
+	case ScalarTypeInteger:
+		scalar.Parse = func(value string) error {
+			_, err := strconv.ParseInt(value, 0, 64)
+
+			return err
+		}
+	case ScalarTypeNumber:
+		scalar.Parse = func(value string) error {
+			_, err := strconv.ParseFloat(value, 64)
+
+			return err
+		}
+	case ScalarTypeBoolean:
+		scalar.Parse = func(value string) error {
+			_, err := strconv.ParseBool(value)
+
+			return err
+		}
+	default:
+		return ScalarType{}, fmt.Errorf(
+			"invalid scalar type %q in %q, expected one of: %s",
+			scalar.Type, definition,
+			strings.Join([]string{ScalarTypeString, ScalarTypeInteger, ScalarTypeNumber, ScalarTypeBoolean}, ", "),
+		)
+	}
+
+	if _, err := scalar.Value(scalar.Sample); err != nil {
+		return ScalarType{}, fmt.Errorf("invalid sample in %q: %w", definition, err)
+	}
+
+	return scalar, nil
+}
+
+// Value returns the value to render: the given value if it's valid or the sample if value is empty.
+func (s ScalarType) Value(value string) (string, error) {
+	if value == "" {
+		return s.Sample, nil
+	}
+
+	if s.Parse == nil {
+		return value, nil
+	}
+
+	if err := s.Parse(value); err != nil {
+		return "", fmt.Errorf("invalid %s value %q: %w", s.Name, value, err)
+	}
+
+	return value, nil
+}
+
+// YAMLTag returns a tag of the YAML value node.
+func (s ScalarType) YAMLTag() string {
+	switch s.Type {
+	case ScalarTypeInteger:
+		return "!!int"
+	case ScalarTypeNumber:
+		return "!!float"
+	case ScalarTypeBoolean:
+		return "!!bool"
+	}
+
+	return "!!str"
+}
+
+// ScalarRegistry is a registry of the types rendered as a single value.
+// Well-known standard library types are always registered,
+// types implementing the marshaler interfaces (see IsMarshaler) are detected as string scalars.
+type ScalarRegistry struct {
+	custom map[string]ScalarType
+}
+
+// NewScalarRegistry creates a registry with the custom scalar types,
+// custom types take precedence over the well-known ones.
+func NewScalarRegistry(custom ...ScalarType) *ScalarRegistry {
+	r := &ScalarRegistry{
+		custom: make(map[string]ScalarType, len(custom)),
+	}
+
+	for _, scalar := range custom {
+		r.custom[scalar.Name] = scalar
+	}
+
+	return r
+}
+
+// Lookup returns the scalar type definition if type (or pointer type element) is a scalar.
+func (r *ScalarRegistry) Lookup(t types.Type) (ScalarType, bool) {
+	if pt, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pt.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ScalarType{}, false
+	}
+
+	pkg := named.Obj().Pkg()
+	name := pkg.Path() + "." + named.Obj().Name()
+
+	if r != nil {
+		for _, key := range []string{name, pkg.Name() + "." + named.Obj().Name()} {
+			if scalar, ok := r.custom[key]; ok {
+				scalar.Name = name
+
+				return scalar, true
+			}
+		}
+	}
+
+	if scalar, ok := wellKnownScalars[name]; ok {
+		scalar.Name = name
+
+		return scalar, true
+	}
+
+	if IsMarshaler(named) {
+		return ScalarType{Name: name, Type: ScalarTypeString}, true
+	}
+
+	return ScalarType{}, false
+}
+
+var wellKnownScalars = map[string]ScalarType{
+	"time.Duration": {
+		Sample: "0s", Format: "duration", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := time.ParseDuration(value)
+
+			return err
+		},
+	},
+	"time.Time": {
+		Sample: "0001-01-01T00:00:00Z", Format: "date-time", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := time.Parse(time.RFC3339Nano, value)
+
+			return err
+		},
+	},
+	"time.Location": {
+		Sample: "UTC", Format: "timezone", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := time.LoadLocation(value)
+
+			return err
+		},
+	},
+	"net/url.URL": {
+		Sample: "", Format: "uri", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := url.Parse(value)
+
+			return err
+		},
+	},
+	"net.IP": {
+		Sample: "127.0.0.1", Format: "ip", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			if net.ParseIP(value) == nil {
+				return errors.New("not an IP address")
+			}
+
+			return nil
+		},
+	},
+	"net.IPNet": {
+		Sample: "127.0.0.0/8", Format: "cidr", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, _, err := net.ParseCIDR(value)
+
+			return err
+		},
+	},
+	"net/netip.Addr": {
+		Sample: "127.0.0.1", Format: "ip", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := netip.ParseAddr(value)
+
+			return err
+		},
+	},
+	"net/netip.AddrPort": {
+		Sample: "127.0.0.1:80", Format: "hostport", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := netip.ParseAddrPort(value)
+
+			return err
+		},
+	},
+	"net/netip.Prefix": {
+		Sample: "127.0.0.0/8", Format: "cidr", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := netip.ParsePrefix(value)
+
+			return err
+		},
+	},
+	"regexp.Regexp": {
+		Sample: ".*", Format: "regex", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			_, err := regexp.Compile(value)
+
+			return err
+		},
+	},
+	"math/big.Int": {
+		Sample: "0", Format: "integer", Type: ScalarTypeInteger,
+		Parse: func(value string) error {
+			if _, ok := new(big.Int).SetString(value, 0); !ok {
+				return errors.New("not an integer")
+			}
+
+			return nil
+		},
+	},
+	"math/big.Float": {
+		Sample: "0", Format: "number", Type: ScalarTypeNumber,
+		Parse: func(value string) error {
+			_, _, err := big.ParseFloat(value, 10, 0, big.ToNearestEven)
+
+			return err
+		},
+	},
+	"encoding/json.RawMessage": {
+		Sample: "null", Format: "json", Type: ScalarTypeString,
+		Parse: func(value string) error {
+			if !json.Valid([]byte(value)) {
+				return errors.New("not a valid JSON")
+			}
+
+			return nil
+		},
+	},
+}
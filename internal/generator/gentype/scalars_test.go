@@ -0,0 +1,103 @@
+package gentype
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarRegistry_Lookup(t *testing.T) {
+	duration := newTestNamed("time", "Duration", types.Typ[types.Int64])
+	prefix := newTestNamed("net/netip", "Prefix", types.NewStruct(nil, nil))
+	unknown := newTestNamed("example.com/pkg", "Duration", types.Typ[types.Int64])
+	custom := newTestNamed("example.com/pkg", "Size", types.NewStruct(nil, nil))
+
+	registry := NewScalarRegistry(ScalarType{Name: "pkg.Size", Type: ScalarTypeInteger, Sample: "1"})
+
+	tests := []struct {
+		Name          string
+		Type          types.Type
+		Value         string
+		ExpectedOK    bool
+		ExpectedValue string
+		ExpectedError bool
+	}{
+		{Name: "duration sample", Type: duration, ExpectedOK: true, ExpectedValue: "0s"},
+		{Name: "duration value", Type: duration, Value: "1h30m", ExpectedOK: true, ExpectedValue: "1h30m"},
+		{Name: "duration invalid", Type: duration, Value: "1 hour", ExpectedOK: true, ExpectedError: true},
+		{Name: "prefix pointer", Type: types.NewPointer(prefix), Value: "10.0.0.0/8", ExpectedOK: true, ExpectedValue: "10.0.0.0/8"},
+		{Name: "prefix invalid", Type: prefix, Value: "10.0.0.0", ExpectedOK: true, ExpectedError: true},
+		{Name: "unknown package", Type: unknown},
+		{Name: "basic", Type: types.Typ[types.String]},
+		{Name: "custom sample", Type: custom, ExpectedOK: true, ExpectedValue: "1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			scalar, ok := registry.Lookup(test.Type)
+			require.Equal(t, test.ExpectedOK, ok)
+
+			if !ok {
+				return
+			}
+
+			value, err := scalar.Value(test.Value)
+			if test.ExpectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedValue, value)
+		})
+	}
+}
+
+func TestParseScalarType(t *testing.T) {
+	tests := []struct {
+		Input         string
+		ExpectedName  string
+		ExpectedType  string
+		ExpectedValue string
+		ExpectedError bool
+	}{
+		{Input: "decimal.Decimal:number", ExpectedName: "decimal.Decimal", ExpectedType: ScalarTypeNumber},
+		{
+			Input:         "github.com/acme/units.Size:integer:1024",
+			ExpectedName:  "github.com/acme/units.Size",
+			ExpectedType:  ScalarTypeInteger,
+			ExpectedValue: "1024",
+		},
+		{Input: "pkg.Clock:string:12:00", ExpectedName: "pkg.Clock", ExpectedType: ScalarTypeString, ExpectedValue: "12:00"},
+		{Input: "pkg.Size:integer:big", ExpectedError: true},
+		{Input: "pkg.Size:object", ExpectedError: true},
+		{Input: "Size:string", ExpectedError: true},
+		{Input: "pkg.Size", ExpectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			scalar, err := ParseScalarType(test.Input)
+			if test.ExpectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedName, scalar.Name)
+			assert.Equal(t, test.ExpectedType, scalar.Type)
+			assert.Equal(t, test.ExpectedValue, scalar.Sample)
+		})
+	}
+}
+
+func newTestNamed(pkgPath string, name string, underlying types.Type) *types.Named {
+	pkg := types.NewPackage(pkgPath, packageNameFromPath(pkgPath))
+
+	return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), underlying, nil)
+}
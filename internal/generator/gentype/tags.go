@@ -9,6 +9,15 @@ const (
 
 	TagDefault = "default"
 	TagExample = "example"
+
+	TagSecret   = "secret"
+	TagFlag     = "flag"
+	TagRequired = "required"
+	TagValidate = "validate"
+
+	TagDeprecated  = "deprecated"
+	TagEnvAliases  = "envAliases"
+	TagYAMLAliases = "yamlAliases"
 )
 
 var (
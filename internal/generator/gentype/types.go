@@ -1,9 +1,11 @@
 package gentype
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"strconv"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -18,9 +20,18 @@ type Source struct {
 
 	CommentsMap map[token.Pos]string
 	SyntaxMap   map[string]*ast.StructType
+
+	// Scalars is a registry of the types rendered as a single value.
+	Scalars *ScalarRegistry
 }
 
-func NewSource(pkg *packages.Package, structName string, named *types.Named, st *types.Struct) Source {
+func NewSource(
+	pkg *packages.Package,
+	structName string,
+	named *types.Named,
+	st *types.Struct,
+	scalars *ScalarRegistry,
+) Source {
 	return Source{
 		Package:        pkg,
 		Struct:         st,
@@ -29,6 +40,7 @@ func NewSource(pkg *packages.Package, structName string, named *types.Named, st
 		RootStructDoc:  GetStructDocComment(pkg, structName),
 		CommentsMap:    BuildCommentsMap(pkg),
 		SyntaxMap:      BuildSyntaxMap(pkg),
+		Scalars:        scalars,
 	}
 }
 
@@ -62,8 +74,50 @@ type OutputOptions struct {
 
 	// TargetPackageName is a target package name if applicable.
 	TargetPackageName string
+
+	// TemplatePath is a template file path if applicable.
+	TemplatePath string
+
+	// Params are the adapter-specific options values, keyed by the option name.
+	Params map[string]string
+}
+
+// BoolParam returns the boolean value of the Params, false if not defined.
+func (o OutputOptions) BoolParam(name string) (bool, error) {
+	value, ok := o.Params[name]
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s option value %q: %w", name, value, err)
+	}
+
+	return enabled, nil
 }
 
+// Names of the OutputOptions.Params values shared by the adapters.
+const (
+	// ParamMerge enables merging of the generated values into the existing target file.
+	ParamMerge = "merge"
+
+	// ParamPrune enables removing of the values missing in the struct from the merged target file.
+	ParamPrune = "prune"
+
+	// ParamEnvTag is a tag name for the dotenv variables names, for the adapters using both YAML and dotenv names.
+	ParamEnvTag = "env-tag"
+
+	// ParamOverlay enables writing only the values differing from the base output.
+	ParamOverlay = "overlay"
+
+	// ParamBaseTag is a field names tag of the base output the overlay is compared to.
+	ParamBaseTag = "base-tag"
+
+	// ParamBaseValueTag is a default value tag of the base output the overlay is compared to.
+	ParamBaseValueTag = "base-value-tag"
+)
+
 type OutputFiles [][]byte
 
 type Nullable[T any] struct {
@@ -1,10 +1,13 @@
 package gentype
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -72,6 +75,12 @@ func ParseNameTag(tagContent string, tagName string, fallback string) string {
 	return parts[0]
 }
 
+// HasTagOption checks if comma-separated tag value contains the option,
+// e.g. `required` in the `PORT,required` or `required,min=1`.
+func HasTagOption(tagValue string, option string) bool {
+	return slices.Contains(strings.Split(tagValue, ","), option)
+}
+
 func ParseDefaultValue(tagValue string, tags ...string) string {
 	if tagValue == "" {
 		return ""
@@ -88,8 +97,34 @@ func ParseDefaultValue(tagValue string, tags ...string) string {
 	return ""
 }
 
+// ParseTags parses the raw struct tag into the map of the tag names to the values.
+// Values are read with the reflect.StructTag, the tags after the malformed part are ignored as in the reflect package.
+func ParseTags(tagContent string) map[string]string {
+	st := reflect.StructTag(tagContent)
+	tags := make(map[string]string)
+
+	for _, match := range pxTagName.FindAllStringSubmatch(tagContent, -1) {
+		name := match[1]
+		if _, exists := tags[name]; exists {
+			continue
+		}
+
+		// Names matched inside the values are not found by the lookup.
+		if value, ok := st.Lookup(name); ok {
+			tags[name] = value
+		}
+	}
+
+	return tags
+}
+
+// pxTagName matches the struct tag names, e.g. `yaml` in the `yaml:"host"`.
+var pxTagName = regexp.MustCompile(`(?:^|\s)([^\s:"]+):"`)
+
 func GetUnderlyingStruct(t types.Type) (*types.Struct, *types.Named, bool) {
 	switch tt := t.(type) {
+	case *types.Alias:
+		return GetUnderlyingStruct(types.Unalias(tt))
 	case *types.Pointer:
 		return GetUnderlyingStruct(tt.Elem())
 	case *types.Named:
@@ -103,12 +138,71 @@ func GetUnderlyingStruct(t types.Type) (*types.Struct, *types.Named, bool) {
 	return nil, nil, false
 }
 
+// TypeInstanceName returns the named type's name
+// with its type arguments appended in camel case, e.g. `Range[int]` -> `RangeInt`.
+// The composite arguments keep their shape in the name,
+// e.g. `Range[[]int]` -> `RangeSliceInt`, `Range[*time.Duration]` -> `RangePtrTimeDuration`.
+// Non-generic types return their plain name.
+func TypeInstanceName(named *types.Named) string {
+	return named.Obj().Name() + typeArgsName(named.TypeArgs())
+}
+
+func typeArgsName(args *types.TypeList) string {
+	name := ""
+
+	for i := 0; i < args.Len(); i++ {
+		name += typeArgName(args.At(i))
+	}
+
+	return name
+}
+
+//nolint:cyclop
+func typeArgName(t types.Type) string {
+	switch tt := t.(type) {
+	case *types.Basic:
+		return ToCamel(tt.Name())
+	case *types.Named:
+		return typeObjectName(tt.Obj()) + typeArgsName(tt.TypeArgs())
+	case *types.Alias:
+		return typeObjectName(tt.Obj()) + typeArgsName(tt.TypeArgs())
+	case *types.Pointer:
+		return "Ptr" + typeArgName(tt.Elem())
+	case *types.Slice:
+		return "Slice" + typeArgName(tt.Elem())
+	case *types.Array:
+		return fmt.Sprintf("Array%d", tt.Len()) + typeArgName(tt.Elem())
+	case *types.Map:
+		return "Map" + typeArgName(tt.Key()) + "To" + typeArgName(tt.Elem())
+	case *types.Chan:
+		return "Chan" + typeArgName(tt.Elem())
+	}
+
+	return ToCamel(types.TypeString(t, func(p *types.Package) string {
+		return p.Name()
+	}))
+}
+
+// typeObjectName returns the type name prefixed with its package name, e.g. `TimeDuration`.
+func typeObjectName(obj *types.TypeName) string {
+	if obj.Pkg() == nil {
+		return ToCamel(obj.Name())
+	}
+
+	return ToCamel(obj.Pkg().Name()) + ToCamel(obj.Name())
+}
+
 func DefaultValueForType(t types.Type, value string) string {
 	if value != "" {
 		return value
 	}
 
-	switch tt := t.(type) {
+	// Text unmarshalers zero value can't be guessed from the underlying type.
+	if IsTextUnmarshaler(t) {
+		return ""
+	}
+
+	switch tt := t.Underlying().(type) {
 	case *types.Basic:
 		switch tt.Kind() {
 		case types.Bool:
@@ -0,0 +1,43 @@
+package gentype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		Input    string
+		Expected map[string]string
+	}{
+		{Input: "", Expected: map[string]string{}},
+		{
+			Input:    `yaml:"host" env:"API_HOST" default:"0.0.0.0"`,
+			Expected: map[string]string{"yaml": "host", "env": "API_HOST", "default": "0.0.0.0"},
+		},
+		{
+			Input:    `json:"name,omitempty" example:"say \"hi\""`,
+			Expected: map[string]string{"json": "name,omitempty", "example": `say "hi"`},
+		},
+		{Input: `yaml:"host" broken`, Expected: map[string]string{"yaml": "host"}},
+		{Input: `yaml:"host" broken env:"HOST"`, Expected: map[string]string{"yaml": "host"}},
+		{
+			Input:    `example:"a b:\"c\"" yaml:"host"`,
+			Expected: map[string]string{"example": `a b:"c"`, "yaml": "host"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			assert.Equal(t, test.Expected, ParseTags(test.Input))
+		})
+	}
+}
+
+func TestHasTagOption(t *testing.T) {
+	assert.True(t, HasTagOption("PORT,required", "required"))
+	assert.True(t, HasTagOption("required,min=1", "required"))
+	assert.False(t, HasTagOption("PORT,notEmpty", "required"))
+	assert.False(t, HasTagOption("", "required"))
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/kukymbr/configen/internal/generator/adapter/yaml"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// AdapterYAMLMultidoc is a name of the multi-document YAML output in the Result.
+const AdapterYAMLMultidoc = "yaml-multidoc"
+
+// yamlMultidoc renders the YAML stream with the document of the main YAML options
+// followed by the documents of the profiles.
+func (g *Generator) yamlMultidoc(ctx context.Context, model *gentype.Model) ([]byte, error) {
+	docs := make([]yaml.Document, 0, len(g.opt.Profiles)+1)
+
+	docs = append(docs, yaml.Document{
+		Name: DefaultProfileName,
+		Options: gentype.OutputOptions{
+			Tag:             g.opt.YAML.Tag,
+			DefaultValueTag: g.opt.YAML.DefaultValueTag,
+		},
+	})
+
+	for _, profile := range g.opt.Profiles {
+		out := profile.Outputs[AdapterYAML]
+
+		docs = append(docs, yaml.Document{
+			Name: profile.Name,
+			Options: gentype.OutputOptions{
+				Tag:             valueOrDefault(out.Tag, profile.YAMLTag),
+				DefaultValueTag: valueOrDefault(out.DefaultValueTag, profile.DefaultValueTag),
+			},
+		})
+	}
+
+	return yaml.GenerateMultiDoc(ctx, model, docs, g.opt.YAMLMultidocKey)
+}
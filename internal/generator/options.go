@@ -2,7 +2,8 @@ package generator
 
 import (
 	"fmt"
-	"path/filepath"
+	"maps"
+	"os"
 	"strings"
 
 	"github.com/kukymbr/configen/internal/generator/gentype"
@@ -13,6 +14,7 @@ const (
 	DefaultEnvTag       = gentype.TagEnv
 	DefaultEnvPrefixTag = gentype.TagEnvPrefix
 	DefaultYAMLTag      = gentype.TagYAML
+	DefaultMaxDepth     = gentype.DefaultMaxRecursionDepth
 )
 
 type Options struct {
@@ -28,9 +30,52 @@ type Options struct {
 	// GoGetter target golang file options.
 	GoGetter gentype.OutputOptions
 
+	// GoFixture target golang test fixture builder file options.
+	// Target struct and package names are equal to the GoGetter ones by default.
+	GoFixture gentype.OutputOptions
+
+	// Flags target golang command line flags bindings file options.
+	// Target struct and package names are equal to the GoGetter ones by default.
+	Flags gentype.OutputOptions
+
 	// SourceDir is a directory of the SQL files.
 	// Default is the current directory (most applicable for go:generate).
 	SourceDir string
+
+	// MaxDepth is a max nesting depth of the structs processing.
+	MaxDepth int
+
+	// ScalarTypes are the custom types to render as a single value.
+	ScalarTypes []gentype.ScalarType
+
+	// Outputs are the outputs options, keyed by the registered adapter name.
+	// Outputs of the built-in adapters are equal to the YAML, Env, GoGetter, GoFixture and Flags fields.
+	// Enable flag is ignored, all given outputs are generated.
+	Outputs map[string]gentype.OutputOptions
+
+	// Templates are the user templates outputs, TemplatePath and Path are required.
+	// Tag, PrefixTag and `env-tag` param define the YAML key, dotenv prefix and dotenv name tags.
+	Templates []gentype.OutputOptions
+
+	// Profiles are the named variants of the outputs generated in the same run, see Profile.
+	Profiles []Profile
+
+	// ProfileReport is a path of the Markdown report listing the values differing between the profiles,
+	// not generated if empty.
+	ProfileReport string
+
+	// YAMLMultidoc is a path of the YAML stream with one document per profile,
+	// starting with the `default` document of the YAML options, not generated if empty.
+	// Profiles may have no outputs if given.
+	YAMLMultidoc string
+
+	// YAMLMultidocKey is a discriminator key added to each YAML stream document with the profile name as a value,
+	// e.g. `environment`. Documents are distinguished by the comments only if empty.
+	YAMLMultidocKey string
+
+	// Registry is a registry of the available adapters.
+	// Default is the registry with the built-in adapters only.
+	Registry *Registry
 }
 
 func (opt Options) Debug() string {
@@ -51,8 +96,20 @@ func prepareOptions(opt *Options) error {
 		opt.SourceDir = DefaultSourceDir
 	}
 
+	if opt.MaxDepth <= 0 {
+		opt.MaxDepth = DefaultMaxDepth
+	}
+
+	if opt.Registry == nil {
+		opt.Registry = NewDefaultRegistry()
+	}
+
 	structSlug := strings.ToLower(opt.StructName)
 
+	if err := prepareOutputs(opt, structSlug); err != nil {
+		return err
+	}
+
 	if opt.YAML.Path == "" {
 		opt.YAML.Path = structSlug + ".yaml"
 	}
@@ -65,6 +122,18 @@ func prepareOptions(opt *Options) error {
 		opt.GoGetter.Path = structSlug + ".gen.go"
 	}
 
+	if opt.GoFixture.Path == "" {
+		opt.GoFixture.Path = structSlug + "_fixture_test.go"
+	}
+
+	if opt.Flags.Path == "" {
+		opt.Flags.Path = structSlug + "_flags.gen.go"
+	}
+
+	if opt.Flags.Tag == "" {
+		opt.Flags.Tag = DefaultYAMLTag
+	}
+
 	if opt.YAML.Tag == "" {
 		opt.YAML.Tag = DefaultYAMLTag
 	}
@@ -77,14 +146,56 @@ func prepareOptions(opt *Options) error {
 		opt.Env.PrefixTag = DefaultEnvPrefixTag
 	}
 
+	// Go getter resolves the aliases of the YAML keys and dotenv variables.
+	if opt.GoGetter.Tag == "" {
+		opt.GoGetter.Tag = opt.YAML.Tag
+	}
+
+	if opt.GoGetter.PrefixTag == "" {
+		opt.GoGetter.PrefixTag = opt.Env.PrefixTag
+	}
+
+	if opt.GoGetter.Params[gentype.ParamEnvTag] == "" {
+		opt.GoGetter.Params = maps.Clone(opt.GoGetter.Params)
+		if opt.GoGetter.Params == nil {
+			opt.GoGetter.Params = make(map[string]string, 1)
+		}
+
+		opt.GoGetter.Params[gentype.ParamEnvTag] = opt.Env.Tag
+	}
+
 	if opt.GoGetter.TargetStructName == "" {
 		opt.GoGetter.TargetStructName = gentype.ToPublicName(opt.StructName)
 	}
 
-	if err := ensureDirs(opt.YAML, opt.Env, opt.GoGetter); err != nil {
+	if opt.GoFixture.TargetStructName == "" {
+		opt.GoFixture.TargetStructName = opt.GoGetter.TargetStructName
+	}
+
+	if opt.GoFixture.TargetPackageName == "" {
+		opt.GoFixture.TargetPackageName = opt.GoGetter.TargetPackageName
+	}
+
+	if opt.Flags.TargetStructName == "" {
+		opt.Flags.TargetStructName = opt.GoGetter.TargetStructName
+	}
+
+	if opt.Flags.TargetPackageName == "" {
+		opt.Flags.TargetPackageName = opt.GoGetter.TargetPackageName
+	}
+
+	if err := prepareTemplates(opt); err != nil {
+		return err
+	}
+
+	if err := prepareProfiles(opt); err != nil {
 		return err
 	}
 
+	if opt.YAMLMultidocKey != "" && opt.YAMLMultidoc == "" {
+		return fmt.Errorf("multi-document YAML path is required for the discriminator key")
+	}
+
 	if err := validateIsDir(opt.SourceDir); err != nil {
 		return err
 	}
@@ -92,14 +203,77 @@ func prepareOptions(opt *Options) error {
 	return nil
 }
 
-func ensureDirs(opts ...gentype.OutputOptions) error {
-	for _, opt := range opts {
-		if dir := filepath.Dir(opt.Path); dir != "" {
-			if err := EnsureDir(dir); err != nil {
-				return err
+func prepareTemplates(opt *Options) error {
+	templates := make([]gentype.OutputOptions, 0, len(opt.Templates))
+
+	for _, out := range opt.Templates {
+		if out.TemplatePath == "" || out.Path == "" {
+			return fmt.Errorf("template and output paths are required for the template output")
+		}
+
+		if _, err := os.Stat(out.TemplatePath); err != nil {
+			return fmt.Errorf("template %s: %w", out.TemplatePath, err)
+		}
+
+		out.Enable = true
+
+		if out.Tag == "" {
+			out.Tag = DefaultYAMLTag
+		}
+
+		if out.PrefixTag == "" {
+			out.PrefixTag = DefaultEnvPrefixTag
+		}
+
+		templates = append(templates, out)
+	}
+
+	opt.Templates = templates
+
+	return nil
+}
+
+// prepareOutputs validates the Options.Outputs
+// and moves the outputs of the built-in adapters into the dedicated fields.
+func prepareOutputs(opt *Options, structSlug string) error {
+	outputs := make(map[string]gentype.OutputOptions, len(opt.Outputs))
+	builtin := opt.builtinOutputs()
+
+	for name, out := range opt.Outputs {
+		if _, ok := opt.Registry.Lookup(name); !ok {
+			return fmt.Errorf("unknown adapter %s, registered adapters: %s", name, strings.Join(opt.Registry.Names(), ", "))
+		}
+
+		out.Enable = true
+
+		if target, ok := builtin[name]; ok {
+			if target.Enable {
+				return fmt.Errorf("output of the %s adapter is defined twice", name)
 			}
+
+			*target = out
+
+			continue
 		}
+
+		if out.Path == "" {
+			out.Path = structSlug + "." + name
+		}
+
+		outputs[name] = out
 	}
 
+	opt.Outputs = outputs
+
 	return nil
 }
+
+func (opt *Options) builtinOutputs() map[string]*gentype.OutputOptions {
+	return map[string]*gentype.OutputOptions{
+		AdapterYAML:      &opt.YAML,
+		AdapterEnv:       &opt.Env,
+		AdapterGoGetter:  &opt.GoGetter,
+		AdapterGoFixture: &opt.GoFixture,
+		AdapterFlags:     &opt.Flags,
+	}
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/changelog"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// AdapterProfileReport is a name of the profiles report output in the Result.
+const AdapterProfileReport = "profile-report"
+
+// DefaultProfileName is a name of the main options outputs in the profiles report.
+const DefaultProfileName = "default"
+
+// Profile is a named variant of the outputs generated from the same struct in one run,
+// e.g. the YAML and dotenv files of the local environment using the `local` tags.
+type Profile struct {
+	// Name is a profile name.
+	Name string
+
+	// YAMLTag is a tag name for the YAML keys, equal to the YAML output tag by default.
+	YAMLTag string
+
+	// EnvTag is a tag name for the dotenv variables names, equal to the Env output tag by default.
+	EnvTag string
+
+	// EnvPrefixTag is a tag name for the dotenv sub-structs prefixes, equal to the Env output prefix tag by default.
+	EnvPrefixTag string
+
+	// DefaultValueTag is an explicit tag name for a default value of the profile outputs.
+	// Prepends the default lookup if given.
+	DefaultValueTag string
+
+	// Outputs are the profile outputs options, keyed by the registered adapter name, Path is required.
+	// Unset options are inherited from the profile tags and the main output of the same adapter.
+	Outputs map[string]gentype.OutputOptions
+}
+
+func prepareProfiles(opt *Options) error {
+	names := make(map[string]bool, len(opt.Profiles))
+	builtin := opt.builtinOutputs()
+
+	opt.Profiles = slices.Clone(opt.Profiles)
+
+	for i := range opt.Profiles {
+		profile := &opt.Profiles[i]
+
+		if err := validateFlagName(profile.Name); err != nil {
+			return fmt.Errorf("profile name: %w", err)
+		}
+
+		if profile.Name == DefaultProfileName {
+			return fmt.Errorf("profile name %s is reserved for the main outputs", DefaultProfileName)
+		}
+
+		if names[profile.Name] {
+			return fmt.Errorf("profile %s is defined twice", profile.Name)
+		}
+
+		names[profile.Name] = true
+
+		if len(profile.Outputs) == 0 && opt.YAMLMultidoc == "" {
+			return fmt.Errorf("profile %s has no outputs", profile.Name)
+		}
+
+		profile.YAMLTag = valueOrDefault(profile.YAMLTag, opt.YAML.Tag)
+		profile.EnvTag = valueOrDefault(profile.EnvTag, opt.Env.Tag)
+		profile.EnvPrefixTag = valueOrDefault(profile.EnvPrefixTag, opt.Env.PrefixTag)
+
+		outputs := make(map[string]gentype.OutputOptions, len(profile.Outputs))
+
+		for name, out := range profile.Outputs {
+			if _, ok := opt.Registry.Lookup(name); !ok {
+				return fmt.Errorf(
+					"profile %s: unknown adapter %s, registered adapters: %s",
+					profile.Name, name, strings.Join(opt.Registry.Names(), ", "),
+				)
+			}
+
+			if out.Path == "" {
+				return fmt.Errorf("profile %s: output path of the %s adapter is required", profile.Name, name)
+			}
+
+			base := opt.Outputs[name]
+			if target, ok := builtin[name]; ok {
+				base = *target
+			}
+
+			outputs[name] = profile.inherit(name, out, base)
+		}
+
+		profile.Outputs = outputs
+	}
+
+	return nil
+}
+
+// inherit fills the unset output options with the profile tags and the main output options.
+func (p Profile) inherit(adapter string, out gentype.OutputOptions, base gentype.OutputOptions) gentype.OutputOptions {
+	out.Enable = true
+	out.DefaultValueTag = valueOrDefault(out.DefaultValueTag, p.DefaultValueTag)
+
+	switch adapter {
+	case AdapterYAML, AdapterGoGetter:
+		out.Tag = valueOrDefault(out.Tag, p.YAMLTag)
+	case AdapterEnv:
+		out.Tag = valueOrDefault(out.Tag, p.EnvTag)
+	}
+
+	out.Tag = valueOrDefault(out.Tag, base.Tag)
+	out.PrefixTag = valueOrDefault(out.PrefixTag, p.EnvPrefixTag)
+	out.TargetStructName = valueOrDefault(out.TargetStructName, base.TargetStructName)
+	out.TargetPackageName = valueOrDefault(out.TargetPackageName, base.TargetPackageName)
+
+	params := maps.Clone(base.Params)
+	if params == nil {
+		params = make(map[string]string, len(out.Params)+1)
+	}
+
+	maps.Copy(params, out.Params)
+
+	if adapter == AdapterGoGetter {
+		params[gentype.ParamEnvTag] = valueOrDefault(out.Params[gentype.ParamEnvTag], p.EnvTag)
+	}
+
+	// The overlay is compared to the main output of the same adapter.
+	if params[gentype.ParamOverlay] != "" {
+		params[gentype.ParamBaseTag] = valueOrDefault(out.Params[gentype.ParamBaseTag], base.Tag)
+		params[gentype.ParamBaseValueTag] = valueOrDefault(out.Params[gentype.ParamBaseValueTag], base.DefaultValueTag)
+	}
+
+	out.Params = params
+
+	return out
+}
+
+// profileReport renders the Markdown table of the values differing between the main options and the profiles.
+// Cells contain the YAML and dotenv values, the omitted recursive structs are skipped.
+func (g *Generator) profileReport(model *gentype.Model) []byte {
+	profiles := append([]Profile{{
+		Name:            DefaultProfileName,
+		YAMLTag:         g.opt.YAML.Tag,
+		EnvTag:          g.opt.Env.Tag,
+		EnvPrefixTag:    g.opt.Env.PrefixTag,
+		DefaultValueTag: g.opt.YAML.DefaultValueTag,
+	}}, g.opt.Profiles...)
+
+	recursive := make(map[string]bool)
+
+	model.Root.Walk(func(node *gentype.Node) bool {
+		if node.RecursiveType() != nil {
+			recursive[node.PathString()] = true
+		}
+
+		return true
+	})
+
+	values := make([]map[string]changelog.Key, len(profiles))
+
+	var paths []string
+
+	for i, profile := range profiles {
+		keys := changelog.Keys(model, changelog.Options{
+			YAMLTag:         profile.YAMLTag,
+			EnvTag:          profile.EnvTag,
+			EnvPrefixTag:    profile.EnvPrefixTag,
+			DefaultValueTag: profile.DefaultValueTag,
+		})
+
+		values[i] = make(map[string]changelog.Key, len(keys))
+
+		for _, key := range keys {
+			if recursive[key.Path] {
+				continue
+			}
+
+			if !slices.Contains(paths, key.Path) {
+				paths = append(paths, key.Path)
+			}
+
+			values[i][key.Path] = key
+		}
+	}
+
+	sb := &strings.Builder{}
+
+	sb.WriteString("# " + gentype.ToPublicName(g.opt.StructName) + " profiles\n\n")
+	sb.WriteString("Values differing between the profiles as `YAML / dotenv`, `-` marks the keys skipped in the output.\n\n")
+	sb.WriteString("| Field |")
+
+	for _, profile := range profiles {
+		sb.WriteString(" " + profile.Name + " |")
+	}
+
+	sb.WriteString("\n|-------|" + strings.Repeat("-----|", len(profiles)) + "\n")
+
+	for _, path := range paths {
+		cells := make([]string, len(profiles))
+
+		for i := range profiles {
+			key, ok := values[i][path]
+			cells[i] = profileReportValue(key.Default, ok && key.YAML != "") + " / " +
+				profileReportValue(key.EnvDefault, ok && key.Env != "")
+		}
+
+		if !slices.ContainsFunc(cells, func(cell string) bool { return cell != cells[0] }) {
+			continue
+		}
+
+		sb.WriteString("| `" + path + "` | " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return []byte(sb.String())
+}
+
+// profileReportValue returns the report cell value of the key default,
+// `-` if the key is skipped in the output and empty string if the default is empty.
+func profileReportValue(value string, ok bool) string {
+	switch {
+	case !ok:
+		return "-"
+	case value == "":
+		return ""
+	}
+
+	return "`" + strings.ReplaceAll(value, "|", "\\|") + "`"
+}
+
+func valueOrDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/kukymbr/configen/internal/generator/adapter/env"
+	"github.com/kukymbr/configen/internal/generator/adapter/gogetter"
+	"github.com/kukymbr/configen/internal/generator/adapter/plugin"
+	"github.com/kukymbr/configen/internal/generator/adapter/yaml"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// Names of the built-in adapters.
+const (
+	AdapterYAML      = "yaml"
+	AdapterEnv       = "env"
+	AdapterGoGetter  = "go"
+	AdapterGoFixture = "go-fixture"
+	AdapterFlags     = "flags"
+	AdapterTemplate  = "template"
+)
+
+// NewRegistry returns an empty adapters registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		adapters: make(map[string]registryEntry),
+	}
+}
+
+// NewDefaultRegistry returns an adapters registry with the built-in adapters registered.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	r.MustRegister(AdapterYAML, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return yaml.New(model, out)
+	}, yaml.Options()...)
+
+	r.MustRegister(AdapterEnv, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return env.New(model, out)
+	}, env.Options()...)
+
+	r.MustRegister(AdapterGoGetter, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return gogetter.New(model, out)
+	}, gogetter.Options()...)
+
+	r.MustRegister(AdapterGoFixture, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return gogetter.NewFixture(model, out)
+	})
+
+	r.MustRegister(AdapterFlags, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return gogetter.NewFlags(model, out)
+	}, gogetter.FlagsOptions()...)
+
+	return r
+}
+
+// Registry is a set of the adapters available for the generation, keyed by the adapter name.
+type Registry struct {
+	mu       sync.RWMutex
+	adapters map[string]registryEntry
+}
+
+type registryEntry struct {
+	factory gentype.AdapterFactory
+	options []gentype.AdapterOption
+}
+
+// Register adds the adapter factory and its options to the registry.
+// Returns an error if adapter with the same name is already registered.
+func (r *Registry) Register(name string, factory gentype.AdapterFactory, options ...gentype.AdapterOption) error {
+	if err := validateFlagName(name); err != nil {
+		return fmt.Errorf("adapter name: %w", err)
+	}
+
+	if factory == nil {
+		return fmt.Errorf("adapter %s: factory is nil", name)
+	}
+
+	for _, opt := range options {
+		if err := validateFlagName(opt.Name); err != nil {
+			return fmt.Errorf("adapter %s option: %w", name, err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.adapters[name]; ok {
+		return fmt.Errorf("adapter %s is already registered", name)
+	}
+
+	r.adapters[name] = registryEntry{
+		factory: factory,
+		options: slices.Clone(options),
+	}
+
+	return nil
+}
+
+// MustRegister adds the adapter factory to the registry, panics on error.
+func (r *Registry) MustRegister(name string, factory gentype.AdapterFactory, options ...gentype.AdapterOption) {
+	if err := r.Register(name, factory, options...); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterPlugin registers an external adapter executable.
+// If executable is empty, the `configen-gen-<name>` executable is searched in the PATH.
+func (r *Registry) RegisterPlugin(name string, executable string) error {
+	if executable == "" {
+		path, err := plugin.LookPath(name)
+		if err != nil {
+			return err
+		}
+
+		executable = path
+	}
+
+	return r.Register(name, func(model *gentype.Model, out gentype.OutputOptions) gentype.Adapter {
+		return plugin.New(model, out, name, executable)
+	})
+}
+
+// Lookup returns the adapter factory by name.
+func (r *Registry) Lookup(name string) (gentype.AdapterFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.adapters[name]
+
+	return entry.factory, ok
+}
+
+// Options returns the options declared by the adapter.
+func (r *Registry) Options(name string) []gentype.AdapterOption {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.adapters[name].options)
+}
+
+// Names returns the sorted names of the registered adapters.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.adapters))
+}
@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"golang.org/x/tools/go/packages"
+)
+
+// SourceFiles returns the absolute paths of the source package Go files, excluding the tests.
+func (g *Generator) SourceFiles() ([]string, error) {
+	pkg, err := g.loadPackage(packages.NeedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkg.GoFiles, nil
+}
+
+// OutputPaths returns the target paths of the enabled outputs,
+// the multi-document YAML and the profiles report if enabled.
+func (g *Generator) OutputPaths() []string {
+	outputs := g.enabledOutputs()
+	paths := make([]string, 0, len(outputs)+2)
+
+	for _, out := range outputs {
+		paths = append(paths, out.options.Path)
+	}
+
+	if g.opt.YAMLMultidoc != "" {
+		paths = append(paths, g.opt.YAMLMultidoc)
+	}
+
+	if g.opt.ProfileReport != "" {
+		paths = append(paths, g.opt.ProfileReport)
+	}
+
+	return paths
+}
@@ -0,0 +1,15 @@
+package collision
+
+// config has a generic instance and a struct with the same generated name.
+type config struct {
+	Count optional[int] `yaml:"count"`
+	Limit optionalInt   `yaml:"limit"`
+}
+
+type optional[T any] struct {
+	Value T `yaml:"value"`
+}
+
+type optionalInt struct {
+	Value int `yaml:"value"`
+}
@@ -0,0 +1,11 @@
+package diff
+
+// config has a field conflicting with the Equal method generated by the diff param.
+type config struct {
+	Limits limitsConfig `yaml:"limits"`
+}
+
+type limitsConfig struct {
+	Max   int  `yaml:"max"`
+	Equal bool `yaml:"equal"`
+}
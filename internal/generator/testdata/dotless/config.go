@@ -0,0 +1,26 @@
+// Package config is a config of the module with a dotless path.
+package config
+
+import "time"
+
+type config struct {
+	// API is an API server configuration.
+	API apiConfig `envPrefix:"API_" yaml:"api"`
+
+	// Mode is an application mode.
+	Mode Mode `env:"MODE" default:"debug" yaml:"mode"`
+}
+
+type apiConfig struct {
+	Host    string        `env:"HOST" default:"localhost" yaml:"host"`
+	Port    int           `env:"PORT" default:"8080" yaml:"port"`
+	Timeout time.Duration `env:"TIMEOUT" default:"5s" yaml:"timeout"`
+}
+
+// Mode is an enum of the module with a dotless path.
+type Mode string
+
+const (
+	ModeDebug   Mode = "debug"
+	ModeRelease Mode = "release"
+)
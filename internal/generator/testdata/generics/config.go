@@ -0,0 +1,14 @@
+package generics
+
+// config has the instances of one generic type with the type arguments of the same element type.
+type config struct {
+	Count   optional[int]            `yaml:"count"`
+	Counts  optional[[]int]          `yaml:"counts"`
+	Limit   optional[*int]           `yaml:"limit"`
+	Weights optional[map[string]int] `yaml:"weights"`
+}
+
+type optional[T any] struct {
+	Value T    `yaml:"value"`
+	Set   bool `yaml:"set"`
+}
@@ -0,0 +1,8 @@
+package profiles
+
+// config has the fields with different YAML and dotenv defaults.
+type config struct {
+	Host    string `yaml:"host" env:"HOST" default:"localhost" envDefault:"0.0.0.0" local:"host" localDefault:"127.0.0.1"`
+	Port    int    `yaml:"port" env:"PORT" default:"8080" local:"port"`
+	Workers int    `yaml:"workers" env:"WORKERS" default:"4" envDefault:"8" local:"-"`
+}
@@ -0,0 +1,10 @@
+{{/* Getters with the Get prefix and pointer receivers. */}}
+{{- define "getters" -}}
+{{ $st := . }}
+{{- range $field := $st.Fields }}
+// Get{{ $field.ExportName }} returns the {{ $field.PathString }} value{{ with $field.Default }} (default {{ . }}){{ end }}.
+func (c *{{ $st.Name }}) Get{{ $field.ExportName }}() {{ if and $field.IsStruct $field.StructInfo.IsAnonymous }}any{{ else }}{{ $field.TypeName }}{{ end }} {
+	return c.{{ $field.Name }}
+}
+{{ end }}
+{{- end }}
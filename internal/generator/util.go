@@ -6,8 +6,6 @@ import (
 	"os"
 	"regexp"
 	"strings"
-
-	"github.com/kukymbr/configen/internal/logger"
 )
 
 const (
@@ -15,7 +13,10 @@ const (
 	filesMode os.FileMode = 0644
 )
 
-var pxIdentifier = regexp.MustCompile(`(?i)^[a-z]+[a-z0-9_]*$`)
+var (
+	pxIdentifier = regexp.MustCompile(`(?i)^[a-z]+[a-z0-9_]*$`)
+	pxFlagName   = regexp.MustCompile(`^[a-z]+[a-z0-9-]*$`)
+)
 
 func validateIdentifier(name string) error {
 	if strings.TrimSpace(name) == "" {
@@ -29,6 +30,15 @@ func validateIdentifier(name string) error {
 	return nil
 }
 
+// validateFlagName checks if name is applicable as a command line flag name part.
+func validateFlagName(name string) error {
+	if !pxFlagName.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid flag name", name)
+	}
+
+	return nil
+}
+
 // validateIsDir checks if path exists and is a directory.
 func validateIsDir(path string) error {
 	stat, err := os.Stat(path)
@@ -51,13 +61,3 @@ func EnsureDir(path string) error {
 
 	return nil
 }
-
-func writeFile(content []byte, target string) error {
-	if err := os.WriteFile(target, content, filesMode); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", target, err)
-	}
-
-	logger.Successf("Generated %s file", target)
-
-	return nil
-}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/kukymbr/configen/internal/generator/validator"
+)
+
+// ValidateFiles are the config files to check against the source struct.
+type ValidateFiles struct {
+	// YAML are the YAML config files paths.
+	YAML []string
+
+	// Env are the dotenv config files paths.
+	Env []string
+}
+
+// Validate checks the config files against the source struct
+// using the YAML and Env options tags, returns the found issues.
+func (g *Generator) Validate(ctx context.Context, files ValidateFiles) ([]validator.Issue, error) {
+	model, err := g.loadModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New(model, validator.Options{
+		YAMLTag:      g.opt.YAML.Tag,
+		EnvTag:       g.opt.Env.Tag,
+		EnvPrefixTag: g.opt.Env.PrefixTag,
+	})
+
+	var issues []validator.Issue
+
+	for _, path := range files.YAML {
+		found, err := v.ValidateYAML(path)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	for _, path := range files.Env {
+		found, err := v.ValidateEnv(path)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,238 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// ValidateEnv checks the dotenv config file: unknown and missing required variables, deprecated aliases,
+// type mismatches and values not in the enum.
+func (v *Validator) ValidateEnv(path string) ([]Issue, error) {
+	vars, err := parseEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &envChecker{
+		Validator: v,
+		vars:      make(map[string]*gentype.Node),
+		aliases:   make(map[string]string),
+	}
+
+	c.collect(v.model.Root, "")
+
+	var issues []Issue
+
+	seen := make(map[string]bool, len(vars))
+
+	for _, envVar := range vars {
+		name := envVar.name
+
+		if current, ok := c.aliases[name]; ok {
+			issues = append(issues, Issue{
+				File:    path,
+				Line:    envVar.line,
+				Key:     name,
+				Message: "deprecated alias of " + current,
+			})
+
+			name = current
+		}
+
+		seen[name] = true
+
+		node, ok := c.vars[name]
+		if !ok {
+			if !c.isRecursive(envVar.name) {
+				issues = append(issues, Issue{File: path, Line: envVar.line, Key: envVar.name, Message: "unknown variable"})
+			}
+
+			continue
+		}
+
+		if msg := c.check(node, envVar.value); msg != "" {
+			issues = append(issues, Issue{File: path, Line: envVar.line, Key: envVar.name, Message: msg})
+		}
+	}
+
+	for name, node := range c.vars {
+		if !seen[name] && c.isRequired(node) {
+			issues = append(issues, Issue{File: path, Key: name, Message: "missing required variable"})
+		}
+	}
+
+	sortIssues(issues)
+
+	return issues, nil
+}
+
+type envChecker struct {
+	*Validator
+
+	// vars are the expected variables, keyed by the name.
+	vars map[string]*gentype.Node
+	// aliases are the current variables names, keyed by the aliases.
+	aliases map[string]string
+	// recursivePrefixes are the prefixes of the omitted recursive structs variables.
+	recursivePrefixes []string
+}
+
+// collect collects the expected variables the same way as the dotenv adapter.
+func (c *envChecker) collect(node *gentype.Node, prefix string) {
+	for _, field := range node.Fields {
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct && field.Named != nil {
+				c.collect(field, prefix)
+			}
+
+			continue
+		}
+
+		if field.IsStructLike() {
+			if field.Kind == gentype.NodeKindOpaque {
+				continue
+			}
+
+			structPrefix := prefix + field.TagValue(c.opt.EnvPrefixTag)
+
+			if field.IsRecursive {
+				c.recursivePrefixes = append(c.recursivePrefixes, structPrefix)
+
+				continue
+			}
+
+			c.collect(field, structPrefix)
+
+			if field.Named == nil {
+				continue
+			}
+		}
+
+		if name := field.Key(c.opt.EnvTag, ""); name != "" {
+			c.vars[prefix+name] = field
+
+			for _, alias := range field.EnvAliases() {
+				c.aliases[prefix+alias] = prefix + name
+			}
+		}
+	}
+}
+
+func (c *envChecker) check(node *gentype.Node, value string) string {
+	if value == "" {
+		if c.isRequired(node) {
+			return "required variable is empty"
+		}
+
+		return ""
+	}
+
+	switch node.Kind {
+	case gentype.NodeKindScalar:
+		if node.Scalar == nil && !isBasic(node.Type) {
+			return ""
+		}
+
+		return checkScalar(node, value)
+	case gentype.NodeKindList:
+		if node.Elem.Kind != gentype.NodeKindScalar {
+			return ""
+		}
+
+		for _, item := range strings.Split(value, ",") {
+			if msg := checkScalar(node.Elem, item); msg != "" {
+				return msg
+			}
+		}
+	case gentype.NodeKindStruct, gentype.NodeKindMap, gentype.NodeKindOpaque:
+	}
+
+	return ""
+}
+
+func (c *envChecker) isRequired(node *gentype.Node) bool {
+	return node.IsRequired() || gentype.HasTagOption(node.TagValue(c.opt.EnvTag), "required")
+}
+
+func (c *envChecker) isRecursive(name string) bool {
+	for _, prefix := range c.recursivePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type envVar struct {
+	name  string
+	value string
+	line  int
+}
+
+// parseEnvFile parses the dotenv file `KEY=value` lines,
+// supporting the comments, `export` prefix and quoted values.
+func parseEnvFile(path string) ([]envVar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	var vars []envVar
+
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text = strings.TrimPrefix(text, "export ")
+
+		name, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("parse %s:%d: expected `KEY=value`", path, line)
+		}
+
+		vars = append(vars, envVar{
+			name:  strings.TrimSpace(name),
+			value: unquoteEnvValue(strings.TrimSpace(value)),
+			line:  line,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		switch value[0] {
+		case '"':
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+		case '\'':
+			if value[len(value)-1] == '\'' {
+				return value[1 : len(value)-1]
+			}
+		}
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value
+}
@@ -0,0 +1,173 @@
+// Package validator checks the config files against the source struct model.
+package validator
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+)
+
+// Options of the config files validation.
+type Options struct {
+	// YAMLTag is a tag name for the YAML keys.
+	YAMLTag string
+
+	// EnvTag is a tag name for the dotenv variables names.
+	EnvTag string
+
+	// EnvPrefixTag is a tag name for the dotenv sub-structs variables prefixes.
+	EnvPrefixTag string
+}
+
+// Issue is a problem found in the config file.
+type Issue struct {
+	// File is a config file path.
+	File string
+
+	// Line is a line number in the file, 0 if unknown.
+	Line int
+
+	// Key is a YAML key path (e.g. `api.port`) or a dotenv variable name.
+	Key string
+
+	// Message is a problem description.
+	Message string
+}
+
+func (i Issue) String() string {
+	location := i.File
+	if i.Line > 0 {
+		location += ":" + strconv.Itoa(i.Line)
+	}
+
+	if i.Key == "" {
+		return location + ": " + i.Message
+	}
+
+	return location + ": " + i.Key + ": " + i.Message
+}
+
+// Validator checks the config files against the config model.
+type Validator struct {
+	model *gentype.Model
+	opt   Options
+}
+
+func New(model *gentype.Model, opt Options) *Validator {
+	if opt.YAMLTag == "" {
+		opt.YAMLTag = gentype.TagYAML
+	}
+
+	if opt.EnvTag == "" {
+		opt.EnvTag = gentype.TagEnv
+	}
+
+	if opt.EnvPrefixTag == "" {
+		opt.EnvPrefixTag = gentype.TagEnvPrefix
+	}
+
+	return &Validator{
+		model: model,
+		opt:   opt,
+	}
+}
+
+// checkScalar validates the scalar value of the node,
+// returns the problem description or empty string if value is valid.
+func checkScalar(node *gentype.Node, value string) string {
+	if node.Scalar != nil {
+		if node.Scalar.Parse != nil {
+			if err := node.Scalar.Parse(value); err != nil {
+				return fmt.Sprintf("invalid %s value %q: %s", node.Scalar.Name, value, err.Error())
+			}
+		}
+	} else if msg := checkBasic(node.Type, value); msg != "" {
+		return msg
+	}
+
+	if len(node.Enum) > 0 && !slices.Contains(node.Enum, value) {
+		return fmt.Sprintf("value %q is not allowed, allowed values: %s", value, strings.Join(node.Enum, ", "))
+	}
+
+	return ""
+}
+
+// sortIssues sorts the issues by the line and the key.
+func sortIssues(issues []Issue) {
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+func isBasic(t types.Type) bool {
+	if pt, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pt.Elem()
+	}
+
+	_, ok := t.Underlying().(*types.Basic)
+
+	return ok
+}
+
+// checkBasic validates the value of the basic type, other types are not checked.
+func checkBasic(t types.Type, value string) string {
+	if pt, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pt.Elem()
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return ""
+	}
+
+	var (
+		expected string
+		err      error
+	)
+
+	number := strings.ReplaceAll(value, "_", "")
+
+	switch info := basic.Info(); {
+	case info&types.IsBoolean != 0:
+		expected = "boolean"
+		_, err = strconv.ParseBool(value)
+	case info&types.IsUnsigned != 0:
+		expected = "unsigned integer"
+		_, err = strconv.ParseUint(number, 0, bitSize(basic))
+	case info&types.IsInteger != 0:
+		expected = "integer"
+		_, err = strconv.ParseInt(number, 0, bitSize(basic))
+	case info&types.IsFloat != 0:
+		expected = "number"
+		_, err = strconv.ParseFloat(number, bitSize(basic))
+	default:
+		return ""
+	}
+
+	if err != nil {
+		return fmt.Sprintf("expected %s (%s), got %q", expected, basic.Name(), value)
+	}
+
+	return ""
+}
+
+func bitSize(t *types.Basic) int {
+	switch t.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	}
+
+	return 64
+}
@@ -0,0 +1,272 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateYAML checks the YAML config file: unknown and missing required keys, deprecated aliases,
+// type mismatches and values not in the enum.
+func (v *Validator) ValidateYAML(path string) ([]Issue, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	c := &yamlChecker{Validator: v, file: path}
+
+	if len(doc.Content) == 0 {
+		c.checkMissing(v.model.Root, "", 0)
+	} else {
+		c.checkStruct(v.model.Root, doc.Content[0], "")
+	}
+
+	sortIssues(c.issues)
+
+	return c.issues, nil
+}
+
+type yamlChecker struct {
+	*Validator
+
+	file   string
+	issues []Issue
+}
+
+func (c *yamlChecker) report(node *yaml.Node, key string, format string, args ...any) {
+	line := 0
+	if node != nil {
+		line = node.Line
+	}
+
+	c.issues = append(c.issues, Issue{
+		File:    c.file,
+		Line:    line,
+		Key:     key,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *yamlChecker) checkStruct(node *gentype.Node, value *yaml.Node, path string) {
+	value = resolveAlias(value)
+	if isNull(value) {
+		return
+	}
+
+	if value.Kind != yaml.MappingNode {
+		c.report(value, path, "expected a mapping, got %s", kindName(value))
+
+		return
+	}
+
+	fields := c.structFields(node)
+	aliases := c.structAliases(node)
+	seen := make(map[string]bool, len(fields))
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		keyNode, valNode := value.Content[i], value.Content[i+1]
+
+		// Merge keys are resolved by the YAML decoder.
+		if keyNode.Tag == "!!merge" {
+			continue
+		}
+
+		key := joinKey(path, keyNode.Value)
+
+		name := keyNode.Value
+
+		if current, ok := aliases[name]; ok {
+			c.report(keyNode, key, "deprecated alias of %s", joinKey(path, current))
+
+			name = current
+		}
+
+		field, ok := fields[name]
+		if !ok {
+			c.report(keyNode, key, "unknown key")
+
+			continue
+		}
+
+		seen[name] = true
+
+		c.checkValue(field, valNode, key)
+	}
+
+	for name, field := range fields {
+		if !seen[name] {
+			c.checkMissingField(field, joinKey(path, name), value.Line)
+		}
+	}
+}
+
+func (c *yamlChecker) checkValue(node *gentype.Node, value *yaml.Node, path string) {
+	value = resolveAlias(value)
+	if isNull(value) {
+		return
+	}
+
+	switch node.Kind {
+	case gentype.NodeKindStruct:
+		if node.IsRecursive {
+			if value.Kind != yaml.MappingNode {
+				c.report(value, path, "expected a mapping, got %s", kindName(value))
+			}
+
+			return
+		}
+
+		c.checkStruct(node, value, path)
+	case gentype.NodeKindList:
+		if value.Kind != yaml.SequenceNode {
+			c.report(value, path, "expected a sequence, got %s", kindName(value))
+
+			return
+		}
+
+		for i, item := range value.Content {
+			c.checkValue(node.Elem, item, path+"["+strconv.Itoa(i)+"]")
+		}
+	case gentype.NodeKindMap:
+		if value.Kind != yaml.MappingNode {
+			c.report(value, path, "expected a mapping, got %s", kindName(value))
+
+			return
+		}
+
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			c.checkValue(node.Elem, value.Content[i+1], joinKey(path, value.Content[i].Value))
+		}
+	case gentype.NodeKindScalar:
+		if value.Kind != yaml.ScalarNode {
+			// Values of the interfaces and other unsupported types are not checked.
+			if node.Scalar == nil && !isBasic(node.Type) {
+				return
+			}
+
+			c.report(value, path, "expected a scalar value, got %s", kindName(value))
+
+			return
+		}
+
+		if msg := checkScalar(node, value.Value); msg != "" {
+			c.report(value, path, "%s", msg)
+		}
+	case gentype.NodeKindOpaque:
+	}
+}
+
+// checkMissing reports the missing required keys of the struct.
+func (c *yamlChecker) checkMissing(node *gentype.Node, path string, line int) {
+	for name, field := range c.structFields(node) {
+		c.checkMissingField(field, joinKey(path, name), line)
+	}
+}
+
+func (c *yamlChecker) checkMissingField(field *gentype.Node, path string, line int) {
+	if field.IsRequired() {
+		c.issues = append(c.issues, Issue{File: c.file, Line: line, Key: path, Message: "missing required key"})
+
+		return
+	}
+
+	if field.Kind == gentype.NodeKindStruct && !field.IsPointer && !field.IsRecursive {
+		c.checkMissing(field, path, line)
+	}
+}
+
+// structFields returns the struct fields keyed by the YAML key, embedded structs fields are inlined.
+func (c *yamlChecker) structFields(node *gentype.Node) map[string]*gentype.Node {
+	fields := make(map[string]*gentype.Node, len(node.Fields))
+
+	for _, field := range node.Fields {
+		key := field.Key(c.opt.YAMLTag, field.Name)
+		if key == "" {
+			continue
+		}
+
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct {
+				for name, embedded := range c.structFields(field) {
+					fields[name] = embedded
+				}
+			}
+
+			continue
+		}
+
+		fields[key] = field
+	}
+
+	return fields
+}
+
+// structAliases returns the current YAML keys of the struct fields keyed by their aliases,
+// embedded structs fields are inlined.
+func (c *yamlChecker) structAliases(node *gentype.Node) map[string]string {
+	aliases := make(map[string]string)
+
+	for _, field := range node.Fields {
+		key := field.Key(c.opt.YAMLTag, field.Name)
+		if key == "" {
+			continue
+		}
+
+		if field.IsEmbedded {
+			if field.Kind == gentype.NodeKindStruct {
+				maps.Copy(aliases, c.structAliases(field))
+			}
+
+			continue
+		}
+
+		for _, alias := range field.YAMLAliases() {
+			aliases[alias] = key
+		}
+	}
+
+	return aliases
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.ScalarNode:
+		return strconv.Quote(node.Value)
+	}
+
+	return "unexpected node"
+}
+
+func joinKey(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kukymbr/configen/internal/logger"
+)
+
+// FileStatus is a write status of the target file.
+type FileStatus string
+
+// Write statuses of the target files.
+const (
+	FileCreated   FileStatus = "created"
+	FileUpdated   FileStatus = "updated"
+	FileUnchanged FileStatus = "unchanged"
+)
+
+// WrittenFile is a target file processed by the Generator.Write.
+type WrittenFile struct {
+	// Path is a target file path.
+	Path string
+
+	// Status is a write status of the file.
+	Status FileStatus
+}
+
+// SummarizeFiles returns the files count per status, e.g. `1 created, 2 unchanged`.
+func SummarizeFiles(files []WrittenFile) string {
+	if len(files) == 0 {
+		return "no files"
+	}
+
+	counts := make(map[FileStatus]int, 3)
+	for _, file := range files {
+		counts[file.Status]++
+	}
+
+	parts := make([]string, 0, len(counts))
+
+	for _, status := range []FileStatus{FileCreated, FileUpdated, FileUnchanged} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// writeFile writes the content to the target file if it differs from the existing one.
+// The content is written to a temporary file renamed over the target,
+// so the target is never left partially written. Permissions of the existing file are kept.
+func writeFile(content []byte, target string) (FileStatus, error) {
+	// The symlink is kept, the file it points to is replaced.
+	path := target
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		path = resolved
+	}
+
+	status, mode, err := compareFile(content, path)
+	if err != nil {
+		return "", err
+	}
+
+	if status == FileUnchanged {
+		logger.Successf("Unchanged %s file", target)
+
+		return status, nil
+	}
+
+	dir := filepath.Dir(path)
+
+	if err := EnsureDir(dir); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", target, err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", target, err)
+	}
+
+	if status == FileCreated {
+		logger.Successf("Created %s file", target)
+	} else {
+		logger.Successf("Updated %s file", target)
+	}
+
+	return status, nil
+}
+
+// compareFile returns the status of the target file write and the permissions to write it with.
+func compareFile(content []byte, target string) (FileStatus, os.FileMode, error) {
+	stat, err := os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return FileCreated, filesMode, nil
+	}
+
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat file %s: %w", target, err)
+	}
+
+	if stat.IsDir() {
+		return "", 0, fmt.Errorf("failed to write file %s: is a directory", target)
+	}
+
+	existing, err := os.ReadFile(target)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read file %s: %w", target, err)
+	}
+
+	if bytes.Equal(existing, content) {
+		return FileUnchanged, stat.Mode().Perm(), nil
+	}
+
+	return FileUpdated, stat.Mode().Perm(), nil
+}
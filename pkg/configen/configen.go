@@ -0,0 +1,222 @@
+// Package configen is a public API of the configen generator.
+//
+// It allows running the generation from the Go code, registering custom adapters
+// and receiving the generated files without writing them to the disk:
+//
+//	registry := configen.NewDefaultRegistry()
+//	registry.MustRegister("toml", newTOMLAdapter)
+//
+//	gen, err := configen.New(configen.Options{
+//		StructName: "config",
+//		SourceDir:  "./internal/config",
+//		YAML:       configen.OutputOptions{Enable: true, Path: "config.yaml"},
+//		Outputs:    map[string]configen.OutputOptions{"toml": {Path: "config.toml"}},
+//		Registry:   registry,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	results, err := gen.GenerateFiles(ctx)
+package configen
+
+import (
+	"github.com/kukymbr/configen/internal/generator"
+	"github.com/kukymbr/configen/internal/generator/adapter/plugin"
+	"github.com/kukymbr/configen/internal/generator/adapter/usertpl"
+	"github.com/kukymbr/configen/internal/generator/changelog"
+	"github.com/kukymbr/configen/internal/generator/gentype"
+	"github.com/kukymbr/configen/internal/generator/validator"
+	"github.com/kukymbr/configen/internal/logger"
+)
+
+// Names of the built-in adapters.
+const (
+	AdapterYAML      = generator.AdapterYAML
+	AdapterEnv       = generator.AdapterEnv
+	AdapterGoGetter  = generator.AdapterGoGetter
+	AdapterGoFixture = generator.AdapterGoFixture
+	AdapterFlags     = generator.AdapterFlags
+	AdapterTemplate  = generator.AdapterTemplate
+)
+
+// Names of the generator-level outputs in the Result.
+const (
+	AdapterProfileReport = generator.AdapterProfileReport
+	AdapterYAMLMultidoc  = generator.AdapterYAMLMultidoc
+)
+
+// Default values of the options.
+const (
+	DefaultSourceDir    = generator.DefaultSourceDir
+	DefaultEnvTag       = generator.DefaultEnvTag
+	DefaultEnvPrefixTag = generator.DefaultEnvPrefixTag
+	DefaultYAMLTag      = generator.DefaultYAMLTag
+	DefaultMaxDepth     = generator.DefaultMaxDepth
+	DefaultProfileName  = generator.DefaultProfileName
+)
+
+// Kinds of the config model nodes.
+const (
+	NodeKindScalar = gentype.NodeKindScalar
+	NodeKindStruct = gentype.NodeKindStruct
+	NodeKindList   = gentype.NodeKindList
+	NodeKindMap    = gentype.NodeKindMap
+	NodeKindOpaque = gentype.NodeKindOpaque
+)
+
+// Scalar types of the values.
+const (
+	ScalarTypeString  = gentype.ScalarTypeString
+	ScalarTypeInteger = gentype.ScalarTypeInteger
+	ScalarTypeNumber  = gentype.ScalarTypeNumber
+	ScalarTypeBoolean = gentype.ScalarTypeBoolean
+)
+
+// Write statuses of the target files.
+const (
+	FileCreated   = generator.FileCreated
+	FileUpdated   = generator.FileUpdated
+	FileUnchanged = generator.FileUnchanged
+)
+
+type (
+	// Generator is a configs generator.
+	Generator = generator.Generator
+
+	// Options are the generator options.
+	Options = generator.Options
+
+	// Result is a generated output of the adapter.
+	Result = generator.Result
+
+	// WrittenFile is a target file processed by the Generator.Write.
+	WrittenFile = generator.WrittenFile
+
+	// FileStatus is a write status of the target file.
+	FileStatus = generator.FileStatus
+
+	// ValidateFiles are the config files to check against the source struct.
+	ValidateFiles = generator.ValidateFiles
+
+	// Issue is a problem found in the config file by the Generator.Validate.
+	Issue = validator.Issue
+
+	// ConfigKey is a config value of the struct returned by the Generator.Keys.
+	ConfigKey = changelog.Key
+
+	// ConfigChange is a change of the config key between two struct versions, see CompareKeys.
+	ConfigChange = changelog.Change
+
+	// Profile is a named variant of the outputs generated from the same struct in one run.
+	Profile = generator.Profile
+
+	// Registry is a set of the adapters available for the generation, keyed by the adapter name.
+	Registry = generator.Registry
+
+	// Adapter generates the output files from the config model.
+	Adapter = gentype.Adapter
+
+	// AdapterFactory creates an adapter for the config model and the output options.
+	AdapterFactory = gentype.AdapterFactory
+
+	// AdapterOption is an option declared by the adapter,
+	// exposed as a `--<adapter>-<option>` command line flag.
+	AdapterOption = gentype.AdapterOption
+
+	// GenericAdapter is a base for the adapters, holding the model and the output options.
+	GenericAdapter = gentype.GenericAdapter
+
+	// OutputOptions are the options of the adapter output.
+	OutputOptions = gentype.OutputOptions
+
+	// OutputFiles are the contents of the generated files.
+	OutputFiles = gentype.OutputFiles
+
+	// Source is a loaded source package and struct.
+	Source = gentype.Source
+
+	// Model is a config struct representation shared by the adapters.
+	Model = gentype.Model
+
+	// Node is a field of the config model.
+	Node = gentype.Node
+
+	// NodeKind is a kind of the config model node.
+	NodeKind = gentype.NodeKind
+
+	// ScalarType is a definition of the type rendered as a single value.
+	ScalarType = gentype.ScalarType
+
+	// MaxDepthError is returned if the structs nesting exceeds the max depth.
+	MaxDepthError = gentype.MaxDepthError
+
+	// TemplateData is a root object of the user template.
+	TemplateData = usertpl.Data
+
+	// TemplateField is a config field in the user template.
+	TemplateField = usertpl.Field
+
+	// PluginRequest is an external adapter input, written as JSON to the plugin stdin.
+	PluginRequest = plugin.Request
+
+	// PluginResponse is an external adapter output, read as JSON from the plugin stdout.
+	PluginResponse = plugin.Response
+
+	// PluginNode is a config model node in the plugin request.
+	PluginNode = plugin.Node
+
+	// PluginFile is a file generated by the external adapter.
+	PluginFile = plugin.File
+)
+
+// New creates a new Generator.
+func New(opt Options) (*Generator, error) {
+	return generator.New(opt)
+}
+
+// NewRegistry returns an empty adapters registry.
+func NewRegistry() *Registry {
+	return generator.NewRegistry()
+}
+
+// NewDefaultRegistry returns an adapters registry with the built-in adapters registered.
+func NewDefaultRegistry() *Registry {
+	return generator.NewDefaultRegistry()
+}
+
+// NewGenericAdapter returns a base for the custom adapter.
+func NewGenericAdapter(model *Model, outputOptions OutputOptions) GenericAdapter {
+	return gentype.NewGenericAdapter(model, outputOptions)
+}
+
+// ParseScalarType parses the scalar type definition
+// in `<[pkg/path.]Type>:<string|integer|number|boolean>[:<sample>]` format.
+func ParseScalarType(definition string) (ScalarType, error) {
+	return gentype.ParseScalarType(definition)
+}
+
+// CompareKeys returns the changes of the config keys between two struct versions.
+func CompareKeys(from []ConfigKey, to []ConfigKey) []ConfigChange {
+	return changelog.Compare(from, to)
+}
+
+// ServePlugin runs the external adapter executable:
+// reads the request from the stdin, generates the files and writes the response to the stdout.
+//
+//	func main() {
+//		err := configen.ServePlugin(func(req *configen.PluginRequest) ([]configen.PluginFile, error) {
+//			return []configen.PluginFile{{Content: render(req.Root)}}, nil
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func ServePlugin(generate func(req *PluginRequest) ([]PluginFile, error)) error {
+	return plugin.Serve(generate)
+}
+
+// SetSilentMode disables the generator messages output.
+func SetSilentMode(silent bool) {
+	logger.SetSilentMode(silent)
+}
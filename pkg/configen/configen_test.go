@@ -0,0 +1,115 @@
+package configen_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kukymbr/configen/pkg/configen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	givenSourceDir  = "../../example"
+	givenStructName = "config"
+)
+
+type pathsAdapter struct {
+	configen.GenericAdapter
+}
+
+func (a *pathsAdapter) Generate(_ context.Context) (configen.OutputFiles, error) {
+	var paths []string
+
+	a.Model.Root.Walk(func(node *configen.Node) bool {
+		if node.Kind == configen.NodeKindScalar && node.Name != "" {
+			paths = append(paths, node.PathString())
+		}
+
+		return true
+	})
+
+	return configen.OutputFiles{[]byte(strings.Join(paths, "\n"))}, nil
+}
+
+func TestGenerator_GenerateFiles(t *testing.T) {
+	configen.SetSilentMode(true)
+
+	registry := configen.NewDefaultRegistry()
+	registry.MustRegister("paths", func(model *configen.Model, out configen.OutputOptions) configen.Adapter {
+		return &pathsAdapter{GenericAdapter: configen.NewGenericAdapter(model, out)}
+	})
+
+	targetDir := t.TempDir()
+
+	gen, err := configen.New(configen.Options{
+		StructName: givenStructName,
+		SourceDir:  givenSourceDir,
+		YAML: configen.OutputOptions{
+			Enable: true,
+			Path:   filepath.Join(targetDir, "config.yaml"),
+		},
+		Outputs: map[string]configen.OutputOptions{
+			"paths": {Path: filepath.Join(targetDir, "config.paths")},
+		},
+		Registry: registry,
+	})
+	require.NoError(t, err)
+
+	results, err := gen.GenerateFiles(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	expectedYAML, err := os.ReadFile(filepath.Join(givenSourceDir, "config.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, configen.AdapterYAML, results[0].Adapter)
+	assert.Equal(t, filepath.Join(targetDir, "config.yaml"), results[0].Path)
+	assert.Equal(t, [][]byte{expectedYAML}, [][]byte(results[0].Files))
+
+	assert.Equal(t, "paths", results[1].Adapter)
+	require.Len(t, results[1].Files, 1)
+	assert.Contains(t, string(results[1].Files[0]), "App.InstanceID\n")
+	assert.Contains(t, string(results[1].Files[0]), "API.PublicURL\n")
+
+	entries, err := os.ReadDir(targetDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestNew_UnknownAdapter(t *testing.T) {
+	configen.SetSilentMode(true)
+
+	_, err := configen.New(configen.Options{
+		StructName: givenStructName,
+		SourceDir:  givenSourceDir,
+		Outputs: map[string]configen.OutputOptions{
+			"toml": {},
+		},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown adapter toml")
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := configen.NewDefaultRegistry()
+
+	assert.Equal(t, []string{"env", "flags", "go", "go-fixture", "yaml"}, registry.Names())
+
+	err := registry.Register(configen.AdapterYAML, func(_ *configen.Model, _ configen.OutputOptions) configen.Adapter {
+		return nil
+	})
+	require.Error(t, err)
+
+	err = registry.Register("bad name", func(_ *configen.Model, _ configen.OutputOptions) configen.Adapter {
+		return nil
+	})
+	require.Error(t, err)
+
+	_, ok := configen.NewRegistry().Lookup(configen.AdapterYAML)
+	assert.False(t, ok)
+}